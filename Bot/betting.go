@@ -0,0 +1,76 @@
+package Bot
+
+import (
+	"fmt"
+	"strings"
+
+	"go-poker-bot/Bot/util"
+)
+
+// BettingStructure decides how much players are allowed to bet and raise,
+// independently of the game being played
+type BettingStructure int
+
+const (
+	NoLimit BettingStructure = iota
+	PotLimit
+	FixedLimit
+)
+
+// The most bets and raises allowed in a betting round of a fixed-limit game
+const maxFixedLimitBets = 4
+
+func (b BettingStructure) String() string {
+	switch b {
+	case PotLimit:
+		return "Pot Limit"
+	case FixedLimit:
+		return "Fixed Limit"
+	default:
+		return "No Limit"
+	}
+}
+
+// ParseBettingStructure parses a betting structure from its abbreviation,
+// e.g. "nl", "pl" or "fl"
+func ParseBettingStructure(s string) (BettingStructure, error) {
+	switch strings.ToLower(s) {
+	case "nl", "nolimit":
+		return NoLimit, nil
+	case "pl", "potlimit":
+		return PotLimit, nil
+	case "fl", "limit", "fixedlimit":
+		return FixedLimit, nil
+	default:
+		return NoLimit, fmt.Errorf("unknown betting structure %q", s)
+	}
+}
+
+// RaiseLimits returns the smallest and largest amounts that a player can
+// raise the current bet by. The bet size is the minimum bet, which is also
+// the only size allowed in fixed-limit games. A player who can't afford the
+// minimum can still raise all in
+func (b BettingStructure) RaiseLimits(player *Player, pm *PotManager, betSize int) (int, int) {
+	allIn := player.MaxBet() - pm.CurBet()
+
+	var minRaise, maxRaise int
+	switch b {
+	case PotLimit:
+		// A pot-sized raise is the size of the pot after calling
+		toCall := pm.CurBet() - player.CurBet
+		minRaise, maxRaise = betSize, pm.Value()+toCall
+	case FixedLimit:
+		minRaise, maxRaise = betSize, betSize
+	default:
+		minRaise, maxRaise = betSize, allIn
+	}
+
+	maxRaise = util.Min(maxRaise, allIn)
+	return util.Min(minRaise, maxRaise), maxRaise
+}
+
+// Capped returns whether no more raises are allowed in a round that has had
+// the given number of bets and raises
+func (b BettingStructure) Capped(bets int) bool {
+	return b == FixedLimit && bets >= maxFixedLimitBets
+}
@@ -0,0 +1,351 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestRaiseLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType string
+		limit    string
+		// Raises made before checking the limits, with 0 meaning a call
+		actions []int
+		min     int
+		max     int
+	}{
+		{name: "No Limit Opening", gameType: "holdem", limit: "nl", min: 2, max: 98},
+		{name: "Pot Limit Opening", gameType: "holdem", limit: "pl", min: 2, max: 5},
+		{name: "Pot Limit After a Call", gameType: "holdem", limit: "pl", actions: []int{0}, min: 2, max: 6},
+		{name: "Pot Limit After a Raise", gameType: "holdem", limit: "pl", actions: []int{5}, min: 5, max: 16},
+		{name: "Fixed Limit", gameType: "holdem", limit: "fl", actions: []int{2}, min: 2, max: 2},
+		{name: "No Limit Omaha", gameType: "plo", limit: "nl", min: 2, max: 98},
+		{name: "Pot Limit Omaha by Default", gameType: "plo", min: 2, max: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.gameType, 3)
+			if tt.limit != "" {
+				game.SetOption([]string{"limit", tt.limit})
+			}
+			game.DealHands()
+
+			for _, action := range tt.actions {
+				if action == 0 {
+					game.Call()
+				} else {
+					game.Raise(action)
+				}
+			}
+
+			min, max := game.RaiseLimits()
+			if min != tt.min || max != tt.max {
+				t.Errorf("expected raises between $%d and $%d, got $%d and $%d", tt.min, tt.max, min, max)
+			}
+		})
+	}
+}
+
+func TestRaiseEnforcesLimits(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.SetOption([]string{"limit", "pl"})
+	game.DealHands()
+
+	game.Raise(1)
+	if bet := game.PotManager.CurBet(); bet != 2 {
+		t.Errorf("expected a raise below the minimum to be rejected, got a bet of $%d", bet)
+	}
+
+	// Pot-sized raises are the most allowed, which is what !pot does
+	game.Raise(50)
+	if bet := game.PotManager.CurBet(); bet != 7 {
+		t.Errorf("expected the raise to be capped at the pot, got a bet of $%d", bet)
+	}
+	game.AllIn()
+	if bet := game.PotManager.CurBet(); bet != 7+16 {
+		t.Errorf("expected a pot-sized raise, got a bet of $%d", bet)
+	}
+}
+
+func TestLimitHoldem(t *testing.T) {
+	game := newTestGame("holdem", 2)
+	game.SetOption([]string{"limit", "fl"})
+	if game.Options.Limit != FixedLimit {
+		t.Fatalf("expected fixed limit, got %s", game.Options.Limit)
+	}
+
+	// Changing the game goes back to its usual structure
+	game.ChangeGameType("plo")
+	if game.Options.Limit != PotLimit {
+		t.Errorf("expected pot limit after changing to Omaha, got %s", game.Options.Limit)
+	}
+	if msg := game.SetOption([]string{"limit", "huge"}); game.Options.Limit != PotLimit {
+		t.Errorf("expected an invalid structure to be rejected, got %q", msg)
+	}
+}
+
+func TestMinimumRaise(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.DealHands()
+	first, second, third := game.Players[0], game.Players[1], game.Players[2]
+
+	for _, amount := range []int{0, -5} {
+		if messages := game.Raise(amount); game.PotManager.CurBet() != 2 || first.PlacedBet {
+			t.Errorf("expected a raise of $%d to be rejected, got %v", amount, messages)
+		}
+	}
+
+	// Raising by less than the big blind isn't allowed
+	if messages := game.Raise(1); game.PotManager.CurBet() != 2 {
+		t.Errorf("expected a raise below the big blind to be rejected, got %v", messages)
+	}
+	game.Raise(4)
+
+	// The next raise has to be at least as big as the last one
+	if messages := game.Raise(3); game.PotManager.CurBet() != 6 || second.PlacedBet {
+		t.Errorf("expected a raise smaller than the last raise to be rejected, got %v", messages)
+	}
+	game.Raise(10)
+	if min, _ := game.RaiseLimits(); min != 10 {
+		t.Errorf("expected the minimum raise to be $10, got $%d", min)
+	}
+
+	// Going all in for less than the minimum is allowed
+	third.Balance = 5
+	game.AllIn()
+	if third.Balance != 0 || game.PotManager.CurBet() != 16 {
+		t.Errorf("expected %s to call all in, got a bet of $%d", third.Name, game.PotManager.CurBet())
+	}
+}
+
+func TestAllInReopensBetting(t *testing.T) {
+	tests := []struct {
+		name    string
+		allIn   int
+		reopens bool
+	}{
+		{name: "Short All In Doesn't Reopen", allIn: 9, reopens: false},
+		{name: "Full Raise All In Reopens", allIn: 10, reopens: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 3)
+			first, second, third := game.Players[0], game.Players[1], game.Players[2]
+			third.Balance = tt.allIn
+			game.DealHands()
+
+			// The first player raises by $4 and the small blind calls, then
+			// the big blind goes all in over the top
+			game.Raise(4)
+			game.Call()
+			game.AllIn()
+			if third.Balance != 0 {
+				t.Fatalf("expected %s to be all in", third.Name)
+			}
+
+			if game.GetCurrentPlayer() != first {
+				t.Fatalf("expected it to be %s's turn", first.Name)
+			}
+			bet := game.PotManager.CurBet()
+			game.Raise(20)
+			if raised := game.PotManager.CurBet() > bet; raised != tt.reopens {
+				t.Errorf("expected raising to be allowed to be %t, got %t", tt.reopens, raised)
+			}
+
+			if !tt.reopens {
+				// Players can still call, and so can the small blind
+				game.Call()
+				if game.GetCurrentPlayer() != second {
+					t.Fatalf("expected it to be %s's turn", second.Name)
+				}
+				if game.PotManager.CanRaise(second) {
+					t.Errorf("expected %s to be unable to raise", second.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestAntes(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.SetOption([]string{"ante", "5"})
+	short, small, big := game.Players[0], game.Players[1], game.Players[2]
+	short.Balance = 3
+	game.DealHands()
+
+	// The antes don't count towards the bet, so only the blinds are owed
+	if bet := game.PotManager.CurBet(); bet != 2 {
+		t.Errorf("expected the current bet to be $2, got $%d", bet)
+	}
+	if small.CurBet != 1 || big.CurBet != 2 {
+		t.Errorf("expected the blinds to have bet $1 and $2, got $%d and $%d", small.CurBet, big.CurBet)
+	}
+	if value := game.PotManager.Value(); value != 16 {
+		t.Errorf("expected $16 in the pot, got $%d", value)
+	}
+
+	// The short stack is all in on their ante, and can only win the antes
+	// that they matched
+	if short.Balance != 0 || game.GetCurrentPlayer() != small {
+		t.Fatalf("expected %s to be all in and %s to act", short.Name, small.Name)
+	}
+	if len(game.PotManager.Pots) != 2 {
+		t.Fatalf("expected a side pot, got %d pots", len(game.PotManager.Pots))
+	}
+	main, side := game.PotManager.Pots[0], game.PotManager.Pots[1]
+	if _, ok := side.Players[short]; ok || main.Amount != 9 || side.Amount != 7 {
+		t.Errorf("expected a $9 main pot and a $7 side pot without %s, got $%d and $%d", short.Name, main.Amount, side.Amount)
+	}
+
+	if msg := game.SetOption([]string{"bbante", "2"}); game.Options.BigBlindAnte != 0 {
+		t.Errorf("expected a big blind ante to be rejected alongside an ante, got %q", msg)
+	}
+}
+
+func TestBigBlindAnte(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance int
+		ante    int
+	}{
+		{name: "Full Ante", balance: 100, ante: 2},
+		{name: "Blind Comes First", balance: 3, ante: 1},
+		{name: "No Ante Left", balance: 2, ante: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 3)
+			game.SetOption([]string{"bbante", "2"})
+			big := game.Players[2]
+			big.Balance = tt.balance
+			game.DealHands()
+
+			if paid := tt.balance - big.Balance; paid != 2+tt.ante {
+				t.Errorf("expected the big blind to pay $%d, got $%d", 2+tt.ante, paid)
+			}
+			if big.CurBet != 2 || game.PotManager.CurBet() != 2 {
+				t.Errorf("expected the ante not to count towards the bet, got $%d", game.PotManager.CurBet())
+			}
+			if value := game.PotManager.Value(); value != 3+tt.ante {
+				t.Errorf("expected $%d in the pot, got $%d", 3+tt.ante, value)
+			}
+			for _, player := range game.Players[:2] {
+				if player.Balance+player.CurBet != 100 {
+					t.Errorf("expected only the big blind to pay an ante, but %s did", player.Name)
+				}
+			}
+		})
+	}
+}
+
+// Returns the total chips held by the players and in the pot
+func totalChips(game *Game) int {
+	total := game.PotManager.Value()
+	for _, player := range game.Players {
+		total += player.Balance
+	}
+	return total
+}
+
+func TestBigBlindAnteShortStack(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.SetOption([]string{"bbante", "10"})
+	game.Players[0].Balance = 1000
+	game.Players[1].Balance = 1000
+	big := game.Players[2]
+	game.DealHands()
+
+	// The big blind can only call $90 after their ante, so the rest of the
+	// bets go into a side pot they can't win
+	game.Raise(93)
+	game.Call()
+	game.Call()
+	if big.Balance != 0 {
+		t.Fatalf("expected %s to be all in, has $%d", big.Name, big.Balance)
+	}
+	if total := totalChips(game); total != 2100 {
+		t.Errorf("expected $2100 in chips, got $%d", total)
+	}
+	pots := game.PotManager.Pots
+	if len(pots) != 2 || pots[0].Amount != 280 || pots[1].Amount != 10 {
+		t.Fatalf("expected a $280 main pot and a $10 side pot, got %+v", pots)
+	}
+	if _, ok := pots[1].Players[big]; ok {
+		t.Errorf("expected %s not to be able to win the side pot", big.Name)
+	}
+}
+
+func TestStraddle(t *testing.T) {
+	tests := []struct {
+		name        string
+		mississippi bool
+		straddler   int
+		first       int
+	}{
+		{name: "Under The Gun", straddler: 3, first: 0},
+		{name: "Mississippi", mississippi: true, straddler: 0, first: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 4)
+			game.SetOption([]string{"straddle", "on"})
+			game.SetOption([]string{"mississippi", onOff(tt.mississippi)})
+			straddler := game.Players[tt.straddler]
+			game.Straddle(straddler.User)
+			game.DealHands()
+
+			if bet := game.PotManager.CurBet(); bet != 4 {
+				t.Fatalf("expected the straddle to make the bet $4, got $%d", bet)
+			}
+			if min, _ := game.RaiseLimits(); min != 4 {
+				t.Errorf("expected the minimum raise to be the straddle, got $%d", min)
+			}
+			if game.GetCurrentPlayer() != game.Players[tt.first] {
+				t.Fatalf("expected %s to act first, got %s", game.Players[tt.first].Name, game.GetCurrentPlayer().Name)
+			}
+
+			// Everyone calls, and the straddler gets the last option
+			for i := 0; i < 3; i++ {
+				game.Call()
+			}
+			if game.GetCurrentPlayer() != straddler || game.Street != 0 {
+				t.Fatalf("expected %s to act last before the flop", straddler.Name)
+			}
+			game.Check()
+			if game.Street != 1 {
+				t.Errorf("expected the flop after the straddler checked")
+			}
+		})
+	}
+}
+
+func TestStraddleRules(t *testing.T) {
+	game := newTestGame("holdem", 4)
+	game.Straddle(game.Players[3].User)
+	if game.Straddler != nil {
+		t.Errorf("expected a straddle to be rejected when they aren't allowed")
+	}
+
+	game.SetOption([]string{"straddle", "on"})
+	for _, i := range []int{0, 1, 2} {
+		if game.Straddle(game.Players[i].User); game.Straddler != nil {
+			t.Errorf("expected %s to be unable to straddle", game.Players[i].Name)
+		}
+	}
+
+	// A straddle after the big blind goes all in still raises the bet to
+	// two big blinds in total
+	game.Players[2].Balance = 1
+	game.Straddle(game.Players[3].User)
+	game.DealHands()
+	if bet := game.PotManager.CurBet(); bet != 4 {
+		t.Errorf("expected the bet to be $4, got $%d", bet)
+	}
+	if game.Straddler != nil {
+		t.Errorf("expected the straddle to only last one hand")
+	}
+}
@@ -1,22 +1,46 @@
 package Bot
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// The directory that the bot keeps its files in
+const dataDir = "data"
+
+// The number of hands !history sends by default, and the most it can send
+const (
+	defaultHistoryHands = 10
+	maxHistoryHands     = 100
+)
+
 type Bot struct {
+	// Mutex to protect the map of games, which is shared with the action clocks
+	mu    sync.Mutex
 	games map[string]*Game
+	// The bankrolls of every player, kept across games
+	ledger *Ledger
+	// The last snapshot saved for each channel, so unchanged games aren't
+	// saved again
+	saved map[string][]byte
 }
 
 func NewBot() *Bot {
 	return &Bot{
 		games: make(map[string]*Game),
+		saved: make(map[string][]byte),
 	}
 }
 
@@ -31,21 +55,43 @@ func Run(token string) {
 	checkNilErr(err)
 
 	bot := NewBot()
+	bot.ledger, err = LoadLedger(filepath.Join(dataDir, "ledger.json"))
+	checkNilErr(err)
+	bot.games, err = LoadGames(filepath.Join(dataDir, "games"))
+	checkNilErr(err)
+	for channelID, game := range bot.games {
+		game.Ledger = bot.ledger
+		bot.saved[channelID], err = encodeGame(game)
+		checkNilErr(err)
+	}
 	discord.AddHandler(bot.newMessage)
 
 	discord.Open()
 	defer discord.Close()
 
+	// Pick the action clocks back up in games that stopped mid-hand
+	for channelID, game := range bot.games {
+		game.mu.Lock()
+		bot.startClock(discord, channelID, game)
+		game.mu.Unlock()
+	}
+
 	fmt.Println("Bot running....")
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, os.Interrupt)
 	<-sc
 }
 
-func (b *Bot) getGame(channelID string) *Game {
+func (b *Bot) getGame(channelID, guildID string) *Game {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	game, exists := b.games[channelID]
 	if !exists {
 		game = NewGame()
+		game.Ledger = b.ledger
+		game.GuildID = guildID
+		game.ChannelID = channelID
 		b.games[channelID] = game
 	}
 	return game
@@ -88,7 +134,27 @@ func (b *Bot) newMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
 		command = fullCmd
 	}
 
-	game := b.getGame(m.ChannelID)
+	// Discards can be sent privately, so they're matched up with the game the
+	// player is in rather than the channel they were sent in
+	if command == "discard" && m.GuildID == "" {
+		b.handleDirectDiscard(s, m, args)
+		return
+	}
+
+	// Bankrolls are kept across games, so they don't need the channel's game
+	if command == "bankroll" {
+		b.handleBankroll(s, m)
+		return
+	}
+
+	// Equity can take a while to work out, so it's done without holding the
+	// game's lock
+	if command == "equity" {
+		b.handleEquity(s, m, args)
+		return
+	}
+
+	game := b.getGame(m.ChannelID, m.GuildID)
 
 	// Lock the game for the duration of command processing
 	game.mu.Lock()
@@ -127,6 +193,116 @@ func (b *Bot) newMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
 		handleOptions(s, m, game, args)
 	case "verbose":
 		handleVerbose(s, m, game)
+	case "draw":
+		handleDraw(s, m, game, args)
+	case "straddle":
+		handleStraddle(s, m, game)
+	case "post":
+		handlePost(s, m, game)
+	case "runtwice":
+		handleRunTwice(s, m, game)
+	case "runonce":
+		handleRunOnce(s, m, game)
+	case "show":
+		handleShow(s, m, game)
+	case "muck":
+		handleMuck(s, m, game)
+	case "rabbit":
+		handleRabbit(s, m, game)
+	case "sitout":
+		handleSitOut(s, m, game)
+	case "back":
+		handleBack(s, m, game)
+	case "leave":
+		handleLeave(s, m, game)
+	case "history":
+		handleHistory(s, m, args)
+	case "discard":
+		handleDiscard(s, m, game, args)
+	}
+
+	b.startClock(s, m.ChannelID, game)
+	b.saveGame(m.ChannelID, game)
+}
+
+// Saves a snapshot of the game if it's changed, so that it can carry on if the
+// bot restarts, along with the histories of any hands it's finished. Games that
+// have ended have their snapshot removed. The game must be locked
+func (b *Bot) saveGame(channelID string, game *Game) {
+	if hands := game.TakeHandHistories(); len(hands) > 0 {
+		if err := AppendHandHistories(historyPath(channelID), hands); err != nil {
+			log.Printf("Error saving hand histories in %s: %v", channelID, err)
+		}
+	}
+
+	var data []byte
+	if game.worthSaving() {
+		var err error
+		if data, err = encodeGame(game); err != nil {
+			log.Printf("Error saving the game in %s: %v", channelID, err)
+			return
+		}
+	}
+
+	b.mu.Lock()
+	unchanged := bytes.Equal(b.saved[channelID], data)
+	if data == nil {
+		delete(b.saved, channelID)
+	} else {
+		b.saved[channelID] = data
+	}
+	b.mu.Unlock()
+	if unchanged {
+		return
+	}
+
+	path := filepath.Join(dataDir, "games", channelID+".json")
+	if data == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Error removing the game in %s: %v", channelID, err)
+		}
+		return
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		log.Printf("Error saving the game in %s: %v", channelID, err)
+	}
+}
+
+// Returns the path of the file that the channel's hand histories are kept in
+func historyPath(channelID string) string {
+	return filepath.Join(dataDir, "histories", channelID+".txt")
+}
+
+// Starts the action clock for the current turn, if the table uses one and it
+// isn't already running. The game must be locked
+func (b *Bot) startClock(s *discordgo.Session, channelID string, game *Game) {
+	turn, ok := game.NeedsClock()
+	if !ok {
+		return
+	}
+	go b.runClock(s, channelID, game, turn)
+}
+
+// Ticks the action clock every second until the turn is over, acting for the
+// player if they run out of time
+func (b *Bot) runClock(s *discordgo.Session, channelID string, game *Game, turn int) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		game.mu.Lock()
+		messages, done := game.Tick(turn)
+		SendChannelMessages(s, channelID, messages)
+		if len(messages) > 0 {
+			b.saveGame(channelID, game)
+		}
+		if done {
+			TellNewChannelHands(s, channelID, game)
+			b.startClock(s, channelID, game)
+			game.mu.Unlock()
+			return
+		}
+		game.mu.Unlock()
 	}
 }
 
@@ -166,15 +342,20 @@ func handleStart(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	}
 
 	SendMessages(s, m, game.DealHands())
-	TellHands(s, m, game)
+	TellNewHands(s, m, game)
 }
 
 func handleFold(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
-	if game.GetState() < HandsDealt || game.GetState() > RiverDealt {
+	if !game.HandInProgress() {
 		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
 		return
 	}
 
+	if game.GetState() != HandsDealt {
+		s.ChannelMessageSend(m.ChannelID, "Wait for everyone to finish drawing or discarding first!")
+		return
+	}
+
 	// Check if it's the current player's turn
 	if !game.IsCurrentPlayer(m.Author) {
 		s.ChannelMessageSend(m.ChannelID, "It's not your turn!")
@@ -182,14 +363,20 @@ func handleFold(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	}
 
 	SendMessages(s, m, game.Fold())
+	TellNewHands(s, m, game)
 }
 
 func handleCall(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
-	if game.GetState() < HandsDealt || game.GetState() > RiverDealt {
+	if !game.HandInProgress() {
 		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
 		return
 	}
 
+	if game.GetState() != HandsDealt {
+		s.ChannelMessageSend(m.ChannelID, "Wait for everyone to finish drawing or discarding first!")
+		return
+	}
+
 	// Check if it's the current player's turn
 	if !game.IsCurrentPlayer(m.Author) {
 		s.ChannelMessageSend(m.ChannelID, "It's not your turn!")
@@ -197,14 +384,20 @@ func handleCall(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	}
 
 	SendMessages(s, m, game.Call())
+	TellNewHands(s, m, game)
 }
 
 func handleRaise(s *discordgo.Session, m *discordgo.MessageCreate, game *Game, args []string) {
-	if game.GetState() < HandsDealt || game.GetState() > RiverDealt {
+	if !game.HandInProgress() {
 		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
 		return
 	}
 
+	if game.GetState() != HandsDealt {
+		s.ChannelMessageSend(m.ChannelID, "Wait for everyone to finish drawing or discarding first!")
+		return
+	}
+
 	// Check if it's the current player's turn
 	if !game.IsCurrentPlayer(m.Author) {
 		s.ChannelMessageSend(m.ChannelID, "It's not your turn!")
@@ -224,14 +417,20 @@ func handleRaise(s *discordgo.Session, m *discordgo.MessageCreate, game *Game, a
 	}
 
 	SendMessages(s, m, game.Raise(amount))
+	TellNewHands(s, m, game)
 }
 
 func handleCheck(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
-	if game.GetState() < HandsDealt || game.GetState() > RiverDealt {
+	if !game.HandInProgress() {
 		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
 		return
 	}
 
+	if game.GetState() != HandsDealt {
+		s.ChannelMessageSend(m.ChannelID, "Wait for everyone to finish drawing or discarding first!")
+		return
+	}
+
 	// Check if it's the current player's turn
 	if !game.IsCurrentPlayer(m.Author) {
 		s.ChannelMessageSend(m.ChannelID, "It's not your turn!")
@@ -239,9 +438,17 @@ func handleCheck(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	}
 
 	SendMessages(s, m, game.Check())
+	TellNewHands(s, m, game)
 }
 
 func handleBuyIn(s *discordgo.Session, m *discordgo.MessageCreate, game *Game, args []string) {
+	// Without a game, the players would be dropped by the next !newgame
+	// without cashing out
+	if game.GetState() == NoGame {
+		s.ChannelMessageSend(m.ChannelID, "No game in progress!")
+		return
+	}
+
 	if len(args) != 1 {
 		s.ChannelMessageSend(m.ChannelID, "Usage: !buyin <amount>")
 		return
@@ -266,7 +473,7 @@ func handleDeal(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	}
 
 	SendMessages(s, m, game.DealHands())
-	TellHands(s, m, game)
+	TellNewHands(s, m, game)
 }
 
 func handleCount(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
@@ -284,17 +491,25 @@ func handleCount(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	status := "Player balances:"
 	for _, p := range players {
 		status += fmt.Sprintf("\n- %s: $%d", p.Name, p.Balance)
+		if p.SittingOut {
+			status += " (sitting out)"
+		}
 	}
 
 	s.ChannelMessageSend(m.ChannelID, status)
 }
 
 func handleAllIn(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
-	if game.GetState() < HandsDealt || game.GetState() > RiverDealt {
+	if !game.HandInProgress() {
 		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
 		return
 	}
 
+	if game.GetState() != HandsDealt {
+		s.ChannelMessageSend(m.ChannelID, "Wait for everyone to finish drawing or discarding first!")
+		return
+	}
+
 	// Check if it's the current player's turn
 	if !game.IsCurrentPlayer(m.Author) {
 		s.ChannelMessageSend(m.ChannelID, "It's not your turn!")
@@ -302,6 +517,107 @@ func handleAllIn(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	}
 
 	SendMessages(s, m, game.AllIn())
+	TellNewHands(s, m, game)
+}
+
+func handleDraw(s *discordgo.Session, m *discordgo.MessageCreate, game *Game, args []string) {
+	if game.GetState() != Drawing {
+		s.ChannelMessageSend(m.ChannelID, "No draw in progress!")
+		return
+	}
+
+	if !game.IsCurrentDrawer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "It's not your turn to draw!")
+		return
+	}
+
+	positions := make([]int, len(args))
+	for i, arg := range args {
+		_, err := fmt.Sscanf(arg, "%d", &positions[i])
+		if err != nil {
+			s.ChannelMessageSend(m.ChannelID, "Usage: !draw [card positions to discard], e.g. !draw 1 3 5")
+			return
+		}
+	}
+
+	SendMessages(s, m, game.Draw(positions))
+
+	// Show the player their new hand if the draw went through
+	if len(positions) > 0 && !game.IsCurrentDrawer(m.Author) {
+		TellHand(s, m, game.GetPlayer(m.Author))
+	}
+	TellNewHands(s, m, game)
+}
+
+func handleDiscard(s *discordgo.Session, m *discordgo.MessageCreate, game *Game, args []string) {
+	if game.GetState() != Discarding {
+		s.ChannelMessageSend(m.ChannelID, "No discard in progress!")
+		return
+	}
+
+	cards, err := ParseCards(strings.Join(args, ""))
+	if err != nil || len(cards) == 0 {
+		s.ChannelMessageSend(m.ChannelID, "Usage: !discard <cards>, e.g. !discard 7h")
+		return
+	}
+
+	SendMessages(s, m, game.Discard(m.Author, cards))
+
+	// Show the player what they kept if the discard went through
+	if !game.IsAwaitingDiscard(m.Author) && game.IsPlayer(m.Author) {
+		TellHand(s, m, game.GetPlayer(m.Author))
+	}
+	TellNewHands(s, m, game)
+}
+
+// Handles a discard sent in a DM, which is applied to whichever game is
+// waiting on the player to discard
+func (b *Bot) handleDirectDiscard(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+	cards, err := ParseCards(strings.Join(args, ""))
+	if err != nil || len(cards) == 0 {
+		s.ChannelMessageSend(m.ChannelID, "Usage: !discard <cards>, e.g. !discard 7h")
+		return
+	}
+
+	b.mu.Lock()
+	games := make(map[string]*Game, len(b.games))
+	for channelID, game := range b.games {
+		games[channelID] = game
+	}
+	b.mu.Unlock()
+
+	for channelID, game := range games {
+		game.mu.Lock()
+		if !game.IsAwaitingDiscard(m.Author) {
+			game.mu.Unlock()
+			continue
+		}
+
+		messages := game.Discard(m.Author, cards)
+		if game.IsAwaitingDiscard(m.Author) {
+			// The discard was rejected, so only tell the player why
+			SendMessages(s, m, messages)
+		} else {
+			SendChannelMessages(s, channelID, messages)
+			TellHand(s, m, game.GetPlayer(m.Author))
+			TellNewHands(s, m, game)
+			b.startClock(s, channelID, game)
+			b.saveGame(channelID, game)
+		}
+		game.mu.Unlock()
+		return
+	}
+
+	s.ChannelMessageSend(m.ChannelID, "You don't need to discard right now!")
+}
+
+func (b *Bot) handleBankroll(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.GuildID == "" {
+		s.ChannelMessageSend(m.ChannelID, "Bankrolls are kept by server, so ask in the server you play in!")
+		return
+	}
+
+	s.ChannelMessageSend(m.ChannelID, b.ledger.Bankroll(m.GuildID, m.Author.ID))
 }
 
 func handleEndGame(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
@@ -310,6 +626,12 @@ func handleEndGame(s *discordgo.Session, m *discordgo.MessageCreate, game *Game)
 		return
 	}
 
+	// Chips in the pot would be lost from everyone's bankroll
+	if game.HandInProgress() {
+		s.ChannelMessageSend(m.ChannelID, "Cannot end the game in the middle of a hand!")
+		return
+	}
+
 	SendMessages(s, m, game.EndGame())
 }
 
@@ -320,7 +642,7 @@ func handleChangeGame(s *discordgo.Session, m *discordgo.MessageCreate, game *Ga
 	}
 
 	if len(args) != 1 {
-		s.ChannelMessageSend(m.ChannelID, "Usage: !change <holdem|plo>")
+		s.ChannelMessageSend(m.ChannelID, "Usage: !change <holdem|plo|plo5|plo6|plo8|bigo|shortdeck|pineapple|crazypineapple|stud|razz|draw|27td>")
 		return
 	}
 
@@ -341,33 +663,240 @@ func handleOptions(s *discordgo.Session, m *discordgo.MessageCreate, game *Game,
 	}
 
 	if len(args) != 2 {
-		s.ChannelMessageSend(m.ChannelID, "Usage: !options [sb|bb|min|max|delay] <amount>")
+		s.ChannelMessageSend(m.ChannelID, "Usage: !options [sb|bb|min|max|delay|ante|bbante|timer|timebank|sitout] <amount>, !options limit <nl|pl|fl> or !options [straddle|mississippi] <on|off>")
 		return
 	}
 
 	s.ChannelMessageSend(m.ChannelID, game.SetOption(args))
 }
 
+func handleStraddle(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if !game.BetweenHands() {
+		s.ChannelMessageSend(m.ChannelID, "You can only straddle before the hand is dealt!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.Straddle(m.Author))
+}
+
+func handlePost(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if game.GetState() == NoGame || game.GetState() == Waiting {
+		s.ChannelMessageSend(m.ChannelID, "No game in progress!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.Post(m.Author))
+}
+
+func handleRunTwice(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if !game.HandInProgress() {
+		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.RunItTwice(m.Author))
+}
+
+func handleRunOnce(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if !game.HandInProgress() {
+		s.ChannelMessageSend(m.ChannelID, "No hand in progress!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.RunOnce(m.Author))
+}
+
+func handleShow(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.Show(m.Author))
+}
+
+func handleMuck(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.Muck(m.Author))
+}
+
+func handleRabbit(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if game.GetState() != NoHands {
+		s.ChannelMessageSend(m.ChannelID, "You can only rabbit hunt after a hand!")
+		return
+	}
+
+	SendMessages(s, m, game.RabbitHunt())
+}
+
+func handleSitOut(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if game.GetState() == NoGame {
+		s.ChannelMessageSend(m.ChannelID, "No game in progress!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.SitOut(m.Author))
+}
+
+func handleBack(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if game.GetState() == NoGame {
+		s.ChannelMessageSend(m.ChannelID, "No game in progress!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.Back(m.Author))
+}
+
+func handleLeave(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	if game.GetState() == NoGame {
+		s.ChannelMessageSend(m.ChannelID, "No game in progress!")
+		return
+	}
+
+	if !game.IsPlayer(m.Author) {
+		s.ChannelMessageSend(m.ChannelID, "You're not in the game!")
+		return
+	}
+
+	SendMessages(s, m, game.Leave(m.Author))
+}
+
+func handleHistory(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+	count := defaultHistoryHands
+	if len(args) > 1 {
+		s.ChannelMessageSend(m.ChannelID, "Usage: !history [number of hands]")
+		return
+	}
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > maxHistoryHands {
+			s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("The number of hands must be between 1 and %d!", maxHistoryHands))
+			return
+		}
+		count = n
+	}
+
+	hands, err := RecentHandHistories(historyPath(m.ChannelID), count)
+	if err != nil {
+		log.Printf("Error reading hand histories in %s: %v", m.ChannelID, err)
+		s.ChannelMessageSend(m.ChannelID, "Couldn't read the hand histories!")
+		return
+	}
+	if len(hands) == 0 {
+		s.ChannelMessageSend(m.ChannelID, "No hands have been played here yet!")
+		return
+	}
+
+	s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("Here are the last %d hands played here.", len(hands)),
+		Files: []*discordgo.File{{
+			Name:        "hands.txt",
+			ContentType: "text/plain",
+			Reader:      strings.NewReader(strings.Join(hands, historySeparator) + "\n"),
+		}},
+	})
+}
+
 func handleVerbose(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
 	s.ChannelMessageSend(m.ChannelID, game.ToggleVerbose())
 }
 
+// Works out equity for the channel's game type, copying the game type so the
+// game doesn't stay locked while the runouts are dealt
+func (b *Bot) handleEquity(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
+	if len(args) < 2 {
+		s.ChannelMessageSend(m.ChannelID, "Usage: !equity <hand> <hand> [...] [board:<cards>] [dead:<cards>]")
+		return
+	}
+
+	game := b.getGame(m.ChannelID, m.GuildID)
+	game.mu.Lock()
+	pt := *game.Type
+	game.mu.Unlock()
+
+	SendMessages(s, m, Equity(&pt, args))
+}
+
+// Tells players their cards if any were dealt face-down since they were last told
+func TellNewHands(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	TellNewChannelHands(s, m.ChannelID, game)
+}
+
+// TellNewChannelHands tells everyone their cards if new face-down cards have
+// been dealt, reporting failures to the given channel
+func TellNewChannelHands(s *discordgo.Session, channelID string, game *Game) {
+	if game.TakeNewHoleCards() {
+		TellChannelHands(s, channelID, game)
+	}
+}
+
 func TellHands(s *discordgo.Session, m *discordgo.MessageCreate, game *Game) {
+	TellChannelHands(s, m.ChannelID, game)
+}
+
+// TellChannelHands sends every player in the pot their cards, reporting
+// failures to the given channel
+func TellChannelHands(s *discordgo.Session, channelID string, game *Game) {
 	// for each player, send them a private message containing their dealt cards
-	for _, player := range game.Players {
-		channel, err := s.UserChannelCreate(player.User.ID)
-		if err != nil {
-			log.Fatal("Error fetching user:", err)
-		}
+	for _, player := range game.playersInPot() {
+		TellChannelHand(s, channelID, player)
+	}
+}
 
-		_, err = s.ChannelMessageSend(channel.ID, fmt.Sprintf("Your cards are: %s", player.PrintHand()))
-		if err != nil {
-			log.Fatal("Error sending DM message:", err)
-			s.ChannelMessageSend(
-				m.ChannelID,
-				fmt.Sprintf("Failed to send %s a DM. Did you disable DM in your privacy settings?", player.Name),
-			)
-		}
+// Sends a player a private message containing their cards
+func TellHand(s *discordgo.Session, m *discordgo.MessageCreate, player *Player) {
+	TellChannelHand(s, m.ChannelID, player)
+}
+
+// TellChannelHand sends a player their cards, reporting failures to the given
+// channel
+func TellChannelHand(s *discordgo.Session, channelID string, player *Player) {
+	channel, err := s.UserChannelCreate(player.User.ID)
+	if err != nil {
+		log.Fatal("Error fetching user:", err)
+	}
+
+	_, err = s.ChannelMessageSend(channel.ID, fmt.Sprintf("Your cards are: %s", player.PrintHand()))
+	if err != nil {
+		log.Fatal("Error sending DM message:", err)
+		s.ChannelMessageSend(
+			channelID,
+			fmt.Sprintf("Failed to send %s a DM. Did you disable DM in your privacy settings?", player.Name),
+		)
 	}
 }
 
@@ -399,10 +928,30 @@ func handleHelp(s *discordgo.Session, m *discordgo.MessageCreate) {
 !raise <amount> - Raise the bet
 !allin - Go all in
 !check - Check if no bet is required
+!draw [positions] - Discard the cards at the given positions and draw new ones, e.g. !draw 1 3 5
+!discard <cards> - Discard cards in Pineapple, here or in a DM, e.g. !discard 7h
 !count - Show player balances
 !options [sb|bb|min|max|delay] <amount> - Show or set game options
+!options [ante|bbante] <amount> - Have everyone pay an ante, or the big blind pay one for the table
+!options [timer|timebank] <seconds> - Give players a time limit to act, draw, discard and agree to run it twice, and a time bank for when it runs out
+!options sitout <orbits> - Remove players who sit out for too many orbits
+!options limit <nl|pl|fl> - Play no limit, pot limit or fixed limit, e.g. for Limit Hold'em
+!options [straddle|mississippi] <on|off> - Allow straddles under the gun, and from the dealer too
+!straddle - Straddle for two big blinds on the next hand
+!post - Post a blind to be dealt in on the next hand, instead of waiting for the big blind
+!runtwice - Offer or agree to deal the rest of the board twice if betting ends early
+!runonce - Turn down running it twice
+!show - Show your hand at showdown, or after the hand if it wasn't shown
+!muck - Muck your hand at showdown this hand when it can't win
+!rabbit - See the rest of the board after everyone folds
+!sitout - Keep your seat without being dealt in
+!back - Come back after sitting out
+!leave - Leave the table with your balance, after the current hand
+!history [n] - Get the last n hands played here (10 by default) as a PokerStars hand history file
+!bankroll - Show your bankroll across every game, and your recent buy-ins and cash-outs
 !endgame - End the current game
-!change <holdem|plo> - Change the game type
+!change <holdem|plo|plo5|plo6|plo8|bigo|shortdeck|pineapple|crazypineapple|stud|razz|draw|27td> - Change the game type
+!equity <hand> <hand> [board:<cards>] [dead:<cards>] - Calculate each hand's equity, e.g. !equity AsKd QhQc board:Js7h2c
 !help - Show this help message
 !verbose - Toggle verbose output mode`
 
@@ -411,7 +960,12 @@ func handleHelp(s *discordgo.Session, m *discordgo.MessageCreate) {
 
 // SendMessages sends multiple messages to a channel with a delay between them
 func SendMessages(s *discordgo.Session, m *discordgo.MessageCreate, messages []string) {
+	SendChannelMessages(s, m.ChannelID, messages)
+}
+
+// SendChannelMessages sends multiple messages to the given channel
+func SendChannelMessages(s *discordgo.Session, channelID string, messages []string) {
 	for _, msg := range messages {
-		s.ChannelMessageSend(m.ChannelID, msg)
+		s.ChannelMessageSend(channelID, msg)
 	}
 }
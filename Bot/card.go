@@ -1,5 +1,10 @@
 package Bot
 
+import (
+	"fmt"
+	"strings"
+)
+
 const (
 	Spade   = "♠"
 	Heart   = "♥"
@@ -7,6 +12,15 @@ const (
 	Club    = "♣"
 )
 
+// The order of the suits from lowest to highest, for breaking ties between
+// cards of the same rank
+var suitOrder = map[string]int{
+	Club:    0,
+	Diamond: 1,
+	Heart:   2,
+	Spade:   3,
+}
+
 type RankInfo struct {
 	Name   string
 	Plural string
@@ -50,6 +64,15 @@ func (c Card) Value() int {
 	return rankInfo[c.Rank].Value
 }
 
+// Returns the card's value when aces are low, from 1 for an ace up to 13
+// for a king
+func (c Card) LowValue() int {
+	if c.Rank == "A" {
+		return 1
+	}
+	return c.Value() + 2
+}
+
 func (c Card) Less(other Card) bool {
 	return c.Value() < other.Value()
 }
@@ -57,3 +80,48 @@ func (c Card) Less(other Card) bool {
 func (c Card) Equal(other Card) bool {
 	return c.Rank == other.Rank
 }
+
+var suitAliases = map[string]string{
+	"s": Spade, Spade: Spade,
+	"h": Heart, Heart: Heart,
+	"d": Diamond, Diamond: Diamond,
+	"c": Club, Club: Club,
+}
+
+var rankAliases = map[string]string{
+	"2": "2", "3": "3", "4": "4", "5": "5", "6": "6", "7": "7", "8": "8", "9": "9",
+	"t": "10", "10": "10", "j": "J", "q": "Q", "k": "K", "a": "A",
+}
+
+// ParseCards parses a run of cards written like "AsKd" or "10h9h", where
+// each card is a rank followed by a suit letter or symbol
+func ParseCards(s string) ([]Card, error) {
+	var cards []Card
+	rest := strings.ToLower(s)
+	for rest != "" {
+		rankLen := 1
+		if strings.HasPrefix(rest, "10") {
+			rankLen = 2
+		}
+		rank, ok := rankAliases[rest[:rankLen]]
+		if !ok {
+			return nil, fmt.Errorf("invalid card rank in %q", s)
+		}
+		rest = rest[rankLen:]
+
+		suit := ""
+		for alias, name := range suitAliases {
+			if strings.HasPrefix(rest, alias) {
+				suit = name
+				rest = rest[len(alias):]
+				break
+			}
+		}
+		if suit == "" {
+			return nil, fmt.Errorf("invalid card suit in %q", s)
+		}
+
+		cards = append(cards, Card{Suit: suit, Rank: rank})
+	}
+	return cards, nil
+}
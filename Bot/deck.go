@@ -8,6 +8,11 @@ import (
 // Deck represents a deck of cards
 type Deck struct {
 	cards []Card
+	// Every card the deck was made with, including ones already dealt
+	all []Card
+	// Cards that players have thrown away, which get shuffled back in if the
+	// deck runs out
+	discards []Card
 }
 
 // NewDeck creates a new deck with the given suits and ranks
@@ -25,21 +30,49 @@ func NewDeck(suits []string, ranks []string) Deck {
 		}
 	}
 
+	d.all = d.cards
+
 	d.Shuffle()
 
 	return d
 }
 
-// Shuffle shuffles the deck
+// AllCards returns every card the deck was made with
+func (d Deck) AllCards() []Card {
+	cards := make([]Card, len(d.all))
+	copy(cards, d.all)
+	return cards
+}
+
+// Shuffle gathers every card back into the deck and shuffles it
 func (d *Deck) Shuffle() {
+	d.cards = make([]Card, len(d.all))
+	copy(d.cards, d.all)
+	d.discards = nil
+
+	shuffleCards(d.cards)
+}
+
+func shuffleCards(cards []Card) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	r.Shuffle(len(d.cards), func(i, j int) {
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	r.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
 	})
 }
 
-// Deal deals n cards from the top of the deck
+// Discard puts cards into the discard pile
+func (d *Deck) Discard(cards ...Card) {
+	d.discards = append(d.discards, cards...)
+}
+
+// Deal deals n cards from the top of the deck. If there aren't enough cards
+// left, the discards are shuffled and put under the rest of the deck first
 func (d *Deck) Deal(n int) []Card {
+	if n > len(d.cards) && len(d.discards) > 0 {
+		shuffleCards(d.discards)
+		d.cards = append(d.cards[:len(d.cards):len(d.cards)], d.discards...)
+		d.discards = nil
+	}
 	if n > len(d.cards) {
 		return nil
 	}
@@ -47,3 +80,8 @@ func (d *Deck) Deal(n int) []Card {
 	d.cards = d.cards[n:]
 	return cards
 }
+
+// Len returns the number of cards left in the deck
+func (d Deck) Len() int {
+	return len(d.cards)
+}
@@ -0,0 +1,41 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestDeckReshufflesDiscards(t *testing.T) {
+	deck := NewDeck([]string{Spade, Heart}, []string{"2", "3", "4", "5"})
+
+	hand := deck.Deal(6)
+	if deck.Len() != 2 {
+		t.Fatalf("expected 2 cards left, got %d", deck.Len())
+	}
+	deck.Discard(hand[:4]...)
+
+	// Drawing more than the stub holds brings the discards back in
+	drawn := deck.Deal(5)
+	if len(drawn) != 5 {
+		t.Fatalf("expected to draw 5 cards, got %d", len(drawn))
+	}
+	if deck.Len() != 1 {
+		t.Errorf("expected 1 card left, got %d", deck.Len())
+	}
+
+	seen := make(map[Card]bool)
+	for _, card := range append(append([]Card{}, hand[4:]...), drawn...) {
+		if seen[card] {
+			t.Errorf("%s was dealt twice", card)
+		}
+		seen[card] = true
+	}
+
+	if deck.Deal(2) != nil {
+		t.Errorf("expected no cards when the deck and discards run out")
+	}
+
+	deck.Shuffle()
+	if deck.Len() != 8 {
+		t.Errorf("expected a full deck after shuffling, got %d", deck.Len())
+	}
+}
@@ -0,0 +1,24 @@
+package Bot
+
+// NewDeuceSevenTripleDraw creates a new Deuce-to-Seven Triple Draw game, a
+// fixed-limit lowball game with three draws where the lowest hand wins
+func NewDeuceSevenTripleDraw() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	return PokerType{
+		GameType: DeuceSevenTripleDrawType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: DeuceSevenBestHand,
+		Streets: []Street{
+			{Name: "the hole cards", Down: 5},
+			{Name: "the first draw", Draw: true},
+			{Name: "the second draw", Draw: true},
+			{Name: "the third draw", Draw: true},
+		},
+		Limit: FixedLimit,
+		String: func() string {
+			return "2-7 Triple Draw"
+		},
+	}
+}
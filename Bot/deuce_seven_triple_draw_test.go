@@ -0,0 +1,92 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestDeuceSevenHands(t *testing.T) {
+	tests := []struct {
+		name   string
+		better string
+		worse  string
+	}{
+		{name: "Number One", better: "7s5h4d3c2s", worse: "7s6h4d3c2s"},
+		{name: "Eight Low Loses to Seven Low", better: "7s6h5d4c2s", worse: "8s5h4d3c2s"},
+		{name: "Straight Counts Against", better: "8s5h4d3c2s", worse: "7s6h5d4c3s"},
+		{name: "Flush Counts Against", better: "Ks5h4d3c2s", worse: "7s5s4s3s2s"},
+		{name: "Ace Is High", better: "Ks5h4d3c2s", worse: "As5h4d3c2s"},
+		{name: "Wheel Isn't a Straight", better: "As5h4d3c2s", worse: "2d2h4s5s6s"},
+		{name: "Pair Loses to Any High Card", better: "AsKhQdJc9s", worse: "2s2h3d4c5s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := mustBestHand(t, DeuceSevenBestHand, nil, mustParseCards(t, tt.better))
+			worse := mustBestHand(t, DeuceSevenBestHand, nil, mustParseCards(t, tt.worse))
+			if !worse.Less(better) {
+				t.Errorf("expected %s to beat %s", better, worse)
+			}
+		})
+	}
+}
+
+func TestDeuceSevenHandNames(t *testing.T) {
+	tests := []struct {
+		cards    string
+		expected string
+	}{
+		{cards: "7s5h4d3c2s", expected: "7-5 low"},
+		{cards: "As5h4d3c2s", expected: "A-5 low"},
+		{cards: "7s6h5d4c3s", expected: "seven-high straight"},
+		{cards: "9s9h4d3c2s", expected: "pair of nines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := mustBestHand(t, DeuceSevenBestHand, nil, mustParseCards(t, tt.cards)).String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFixedLimitBetting(t *testing.T) {
+	game := newTestGame("27td", 3)
+	game.DealHands()
+
+	// Raises before the second draw are the size of the big blind, and the
+	// blind counts as the first of four bets
+	game.Raise(50)
+	if bet := game.PotManager.CurBet(); bet != 4 {
+		t.Errorf("expected the bet to be $4, got $%d", bet)
+	}
+	game.Raise(50)
+	game.Raise(50)
+	if bet := game.PotManager.CurBet(); bet != 8 {
+		t.Errorf("expected the bet to be capped at $8, got $%d", bet)
+	}
+	if messages := game.Raise(50); game.PotManager.CurBet() != 8 {
+		t.Errorf("expected raising to be capped, got %v", messages)
+	}
+	game.AllIn()
+	game.Call()
+	if game.GetState() != Drawing {
+		t.Fatalf("expected the first draw to start")
+	}
+
+	game.Draw(nil)
+	game.Draw(nil)
+	game.Draw(nil)
+	game.Check()
+	game.Check()
+	game.Check()
+	for range game.Players {
+		game.Draw(nil)
+	}
+
+	// After the second draw, bets double
+	game.Raise(1)
+	if bet := game.PotManager.CurBet(); bet != 4 {
+		t.Errorf("expected the bet to be $4, got $%d", bet)
+	}
+}
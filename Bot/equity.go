@@ -0,0 +1,261 @@
+package Bot
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"go-poker-bot/Bot/util"
+)
+
+// Boards with more possible runouts than this are sampled instead of
+// being enumerated exhaustively
+const maxExhaustiveRunouts = 100000
+
+// The number of runouts sampled when enumeration isn't feasible
+const monteCarloSamples = 20000
+
+// HandEquity is how a single hand fares over every runout considered
+type HandEquity struct {
+	Hole []Card
+	// The number of runouts where the hand won the whole pot
+	Wins int
+	// The number of runouts where the hand won part of the pot
+	Ties int
+	// The total share of the pot won, summed over every runout
+	share float64
+}
+
+// EquityResult holds the equity of each hand in a calculation
+type EquityResult struct {
+	Hands []HandEquity
+	// The number of runouts that were evaluated
+	Runouts int
+	// Whether every possible runout was evaluated, rather than a sample
+	Exhaustive bool
+}
+
+// Returns the percentage of runouts that the hand won outright
+func (e HandEquity) WinPercent(runouts int) float64 {
+	return 100 * float64(e.Wins) / float64(runouts)
+}
+
+// Returns the percentage of runouts where the hand won part of the pot
+func (e HandEquity) TiePercent(runouts int) float64 {
+	return 100 * float64(e.Ties) / float64(runouts)
+}
+
+// Returns the percentage of the pot that the hand wins on average
+func (e HandEquity) EquityPercent(runouts int) float64 {
+	return 100 * e.share / float64(runouts)
+}
+
+// CalculateEquity works out how often each set of hole cards wins, given a
+// partial board and cards known to be out of the deck
+func CalculateEquity(pt *PokerType, hands [][]Card, board []Card, dead []Card) (EquityResult, error) {
+	boardSize := pt.BoardSize()
+	if boardSize == 0 {
+		return EquityResult{}, fmt.Errorf("equity can only be calculated for games with community cards")
+	}
+	if len(hands) < 2 {
+		return EquityResult{}, fmt.Errorf("at least two hands are needed")
+	}
+	for _, hand := range hands {
+		if len(hand) != pt.HoleCards() {
+			return EquityResult{}, fmt.Errorf("each hand needs exactly %d cards in %s", pt.HoleCards(), pt.String())
+		}
+	}
+	if len(board) > boardSize {
+		return EquityResult{}, fmt.Errorf("the board can't have more than %d cards", boardSize)
+	}
+
+	// Remove every known card from the deck, checking for duplicates
+	known := make(map[Card]struct{})
+	var used []Card
+	for _, hand := range hands {
+		used = append(used, hand...)
+	}
+	used = append(used, board...)
+	used = append(used, dead...)
+	for _, card := range used {
+		if _, ok := known[card]; ok {
+			return EquityResult{}, fmt.Errorf("%s appears more than once", card)
+		}
+		known[card] = struct{}{}
+	}
+
+	var remaining []Card
+	for _, card := range pt.Deck.AllCards() {
+		if _, ok := known[card]; ok {
+			delete(known, card)
+			continue
+		}
+		remaining = append(remaining, card)
+	}
+	for card := range known {
+		return EquityResult{}, fmt.Errorf("%s isn't in the deck for %s", card, pt.String())
+	}
+
+	need := boardSize - len(board)
+	if need > len(remaining) {
+		return EquityResult{}, fmt.Errorf("not enough cards left in the deck to complete the board")
+	}
+
+	result := EquityResult{
+		Hands: make([]HandEquity, len(hands)),
+	}
+	for i, hand := range hands {
+		result.Hands[i].Hole = hand
+	}
+
+	community := make([]Card, boardSize)
+	copy(community, board)
+
+	if runouts := binomial(len(remaining), need); runouts <= maxExhaustiveRunouts {
+		result.Exhaustive = true
+		combinations := util.Combinations(remaining, need)
+		for runout := range combinations {
+			copy(community[len(board):], runout)
+			if err := result.score(pt, community); err != nil {
+				// Drain the rest of the runouts so that the goroutine making
+				// them doesn't block forever
+				for range combinations {
+				}
+				return EquityResult{}, err
+			}
+		}
+	} else {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for i := 0; i < monteCarloSamples; i++ {
+			// Partially shuffle the remaining cards to draw the runout
+			for j := 0; j < need; j++ {
+				k := j + r.Intn(len(remaining)-j)
+				remaining[j], remaining[k] = remaining[k], remaining[j]
+			}
+			copy(community[len(board):], remaining[:need])
+			if err := result.score(pt, community); err != nil {
+				return EquityResult{}, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Awards a single completed board to the best hands, splitting it between
+// high and low hands in split-pot games
+func (r *EquityResult) score(pt *PokerType, community []Card) error {
+	shares := make([]float64, len(r.Hands))
+
+	highShare := 1.0
+	if pt.LowHand != nil {
+		lowWinners, err := r.bestHands(pt.LowHand, community)
+		if err != nil {
+			return err
+		}
+		if len(lowWinners) > 0 {
+			highShare = 0.5
+			for _, i := range lowWinners {
+				shares[i] += 0.5 / float64(len(lowWinners))
+			}
+		}
+	}
+	highWinners, err := r.bestHands(pt.BestHand, community)
+	if err != nil {
+		return err
+	}
+	for _, i := range highWinners {
+		shares[i] += highShare / float64(len(highWinners))
+	}
+
+	for i, share := range shares {
+		if share == 1 {
+			r.Hands[i].Wins++
+		} else if share > 0 {
+			r.Hands[i].Ties++
+		}
+		r.Hands[i].share += share
+	}
+	r.Runouts++
+	return nil
+}
+
+// Returns the indices of the hands that make the best hand on the board
+func (r *EquityResult) bestHands(bestHandFunc BestHandFunc, community []Card) ([]int, error) {
+	var best Hand
+	var winners []int
+	for i, hand := range r.Hands {
+		h, err := bestHandFunc(community, hand.Hole)
+		if err != nil {
+			return nil, err
+		}
+		if h.Rank == 0 {
+			continue
+		}
+		if best.Rank == 0 || best.Less(h) {
+			best = h
+			winners = []int{i}
+		} else if h.Equal(best) {
+			winners = append(winners, i)
+		}
+	}
+	return winners, nil
+}
+
+// Returns the number of ways of choosing k items out of n
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
+
+// Equity calculates the equity of the hands given in the arguments in the game
+// type, e.g. "AsKd QhQc board:Js7h2c dead:2d". It doesn't touch the game, so
+// it can run without holding up the table
+func Equity(pt *PokerType, args []string) []string {
+	var hands [][]Card
+	var board, dead []Card
+	for _, arg := range args {
+		lower := strings.ToLower(arg)
+		var cards []Card
+		var err error
+		switch {
+		case strings.HasPrefix(lower, "board:"):
+			board, err = ParseCards(arg[len("board:"):])
+		case strings.HasPrefix(lower, "dead:"):
+			dead, err = ParseCards(arg[len("dead:"):])
+		default:
+			cards, err = ParseCards(arg)
+			hands = append(hands, cards)
+		}
+		if err != nil {
+			return []string{fmt.Sprintf("Couldn't read cards: %s.", err)}
+		}
+	}
+
+	result, err := CalculateEquity(pt, hands, board, dead)
+	if err != nil {
+		return []string{fmt.Sprintf("Couldn't calculate equity: %s.", err)}
+	}
+
+	method := "Monte Carlo sampling of"
+	if result.Exhaustive {
+		method = "all"
+	}
+	lines := []string{fmt.Sprintf("%s equity over %s %d runouts:", pt.String(), method, result.Runouts)}
+	for _, hand := range result.Hands {
+		lines = append(lines, fmt.Sprintf("%s: %.2f%% equity (win %.2f%%, tie %.2f%%)",
+			printCards(hand.Hole),
+			hand.EquityPercent(result.Runouts),
+			hand.WinPercent(result.Runouts),
+			hand.TiePercent(result.Runouts),
+		))
+	}
+	return []string{strings.Join(lines, "\n")}
+}
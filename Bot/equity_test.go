@@ -0,0 +1,162 @@
+package Bot
+
+import (
+	"math"
+	"testing"
+)
+
+func mustParseCards(t *testing.T, s string) []Card {
+	t.Helper()
+	cards, err := ParseCards(s)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", s, err)
+	}
+	return cards
+}
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Card
+		err      bool
+	}{
+		{input: "AsKd", expected: []Card{{Suit: Spade, Rank: "A"}, {Suit: Diamond, Rank: "K"}}},
+		{input: "10h9H", expected: []Card{{Suit: Heart, Rank: "10"}, {Suit: Heart, Rank: "9"}}},
+		{input: "Tc2♠", expected: []Card{{Suit: Club, Rank: "10"}, {Suit: Spade, Rank: "2"}}},
+		{input: "1s", err: true},
+		{input: "Ax", err: true},
+		{input: "A", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cards, err := ParseCards(tt.input)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected an error, got %v", cards)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(cards) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, cards)
+			}
+			for i := range cards {
+				if cards[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, cards)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateEquity(t *testing.T) {
+	holdem := NewTexasHoldem()
+	omaha := NewPotLimitOmaha()
+
+	tests := []struct {
+		name       string
+		pokerType  *PokerType
+		hands      []string
+		board      string
+		dead       string
+		equity     []float64
+		tolerance  float64
+		exhaustive bool
+	}{
+		{
+			name:       "Overcards on the Turn",
+			pokerType:  &holdem,
+			hands:      []string{"AsKd", "QhQc"},
+			board:      "Js7h2c5d",
+			equity:     []float64{100 * 6.0 / 44, 100 * 38.0 / 44},
+			exhaustive: true,
+		},
+		{
+			name:       "Dead Cards Remove Outs",
+			pokerType:  &holdem,
+			hands:      []string{"AsKd", "QhQc"},
+			board:      "Js7h2c5d",
+			dead:       "AhAcKs",
+			equity:     []float64{100 * 3.0 / 41, 100 * 38.0 / 41},
+			exhaustive: true,
+		},
+		{
+			name:       "Chopped Board",
+			pokerType:  &holdem,
+			hands:      []string{"2s3d", "2h3c"},
+			board:      "AsKdQhJc10s",
+			equity:     []float64{50, 50},
+			exhaustive: true,
+		},
+		{
+			name:      "Aces Against Kings Preflop",
+			pokerType: &holdem,
+			hands:     []string{"AsAd", "KhKc"},
+			equity:    []float64{82, 18},
+			tolerance: 2,
+		},
+		{
+			name:       "Omaha on the River",
+			pokerType:  &omaha,
+			hands:      []string{"AsKs2d3d", "QhQcJh10c"},
+			board:      "Qs7s4s8h9d",
+			equity:     []float64{100, 0},
+			exhaustive: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hands [][]Card
+			for _, hand := range tt.hands {
+				hands = append(hands, mustParseCards(t, hand))
+			}
+			board := mustParseCards(t, tt.board)
+			dead := mustParseCards(t, tt.dead)
+
+			result, err := CalculateEquity(tt.pokerType, hands, board, dead)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Exhaustive != tt.exhaustive {
+				t.Errorf("expected exhaustive to be %t", tt.exhaustive)
+			}
+			for i, hand := range result.Hands {
+				equity := hand.EquityPercent(result.Runouts)
+				if math.Abs(equity-tt.equity[i]) > tt.tolerance+1e-9 {
+					t.Errorf("hand %d: expected %.2f%% equity, got %.2f%%", i, tt.equity[i], equity)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateEquityErrors(t *testing.T) {
+	holdem := NewTexasHoldem()
+
+	tests := []struct {
+		name  string
+		hands []string
+		board string
+	}{
+		{name: "One Hand", hands: []string{"AsKd"}},
+		{name: "Wrong Number of Hole Cards", hands: []string{"AsKdQd", "QhQc"}},
+		{name: "Duplicate Card", hands: []string{"AsKd", "AsQc"}},
+		{name: "Board Too Long", hands: []string{"AsKd", "QhQc"}, board: "2c3c4c5c6c7c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hands [][]Card
+			for _, hand := range tt.hands {
+				hands = append(hands, mustParseCards(t, hand))
+			}
+			if _, err := CalculateEquity(&holdem, hands, mustParseCards(t, tt.board), nil); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package Bot
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"go-poker-bot/Bot/util"
+)
+
+// HandScore is a comparable strength for a five-card hand. Higher scores
+// are stronger hands, and hands of equal strength share the same score
+type HandScore int
+
+// Primes used to identify each rank, so that the product of a hand's primes
+// is unique to the ranks in that hand regardless of order
+var rankPrimes = [13]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+var suitBits = map[string]uint32{
+	Spade:   0x1000,
+	Heart:   0x2000,
+	Diamond: 0x4000,
+	Club:    0x8000,
+}
+
+// Encodes a card into the packed integer form used by the evaluator:
+//
+//	xxxbbbbb bbbbbbbb shdcrrrr xxpppppp
+//
+// where b is a bit set for the card's rank, s/h/d/c is the suit, r is the
+// rank's value and p is the rank's prime
+func encodeCard(c Card) uint32 {
+	value := c.Value()
+	return 1<<(16+value) | suitBits[c.Suit] | uint32(value)<<8 | rankPrimes[value]
+}
+
+func encodeCards(cards []Card) []uint32 {
+	codes := make([]uint32, len(cards))
+	for i, card := range cards {
+		codes[i] = encodeCard(card)
+	}
+	return codes
+}
+
+// The largest number of cards the evaluator precomputes combinations for
+const maxEvalCards = 10
+
+// Returns an error unless there are enough cards to make a five-card hand,
+// and few enough for the evaluator to handle
+func checkHandSize(n int) error {
+	if n < 5 || n > maxEvalCards {
+		return fmt.Errorf("a hand needs between 5 and %d cards, got %d", maxEvalCards, n)
+	}
+	return nil
+}
+
+// Precomputed index combinations, where combos[n][k] holds every way of
+// picking k indices out of n
+var combos [maxEvalCards + 1][6][][]int
+
+// evaluator holds the lookup tables used to score five-card hands under a
+// variant's hand rankings
+type evaluator struct {
+	// Masks of the ranks in each straight, from lowest to highest
+	straights []uint32
+	// Scores for flushes, indexed by the bitmask of the ranks in the hand
+	flushes [1 << 13]HandScore
+	// Scores for hands with five distinct ranks that aren't flushes
+	unique5 [1 << 13]HandScore
+	// Scores for hands with paired ranks, keyed by the product of rank primes
+	products map[uint32]HandScore
+	// The ranking of each score
+	rankings []HandRanking
+
+	// Tables for scoring up to seven cards at once, built the first time
+	// they're needed
+	direct sync.Once
+	// The best flush out of five to seven cards of a suit, indexed by the
+	// bitmask of their ranks
+	bestFlushes [1 << 13]HandScore
+	// The best hand out of six or seven cards without a flush, keyed by the
+	// product of their rank primes
+	bestProducts map[uint64]HandScore
+}
+
+// Returns the mask of five consecutive ranks starting at the given value
+func straightMask(low int) uint32 {
+	return 0b11111 << low
+}
+
+// The mask of a straight where the ace plays low below the given value
+func wheelMask(low int) uint32 {
+	return 1<<12 | 0b1111<<low
+}
+
+// Standard hand rankings, where the wheel (A-2-3-4-5) is the lowest straight
+var standardEvaluator = newEvaluator(
+	[]HandRanking{HighCard, Pair, TwoPair, ThreeOfKind, Straight, Flush, FullHouse, FourOfKind, StraightFlush},
+	[]uint32{
+		wheelMask(0), straightMask(0), straightMask(1), straightMask(2), straightMask(3),
+		straightMask(4), straightMask(5), straightMask(6), straightMask(7), straightMask(8),
+	},
+)
+
+// Short deck hand rankings, where a flush beats a full house and A-6-7-8-9
+// is the lowest straight
+var shortDeckEvaluator = newEvaluator(
+	[]HandRanking{HighCard, Pair, TwoPair, ThreeOfKind, Straight, FullHouse, Flush, FourOfKind, StraightFlush},
+	[]uint32{
+		wheelMask(4), straightMask(4), straightMask(5), straightMask(6), straightMask(7), straightMask(8),
+	},
+)
+
+func init() {
+	for n := 0; n <= maxEvalCards; n++ {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		for k := 0; k <= 5 && k <= n; k++ {
+			for combo := range util.Combinations(indices, k) {
+				combos[n][k] = append(combos[n][k], combo)
+			}
+		}
+	}
+}
+
+// A distinct class of five-card hands, before being assigned a score
+type handClass struct {
+	rank HandRanking
+	// Values that break ties within the hand ranking, most significant first
+	kickers []int
+	// Where the class's score is stored in the lookup tables
+	flush   bool
+	mask    uint32
+	product uint32
+}
+
+// Returns a key that sorts hand classes from weakest to strongest, given the
+// strength of each hand ranking
+func (c handClass) key(strength map[HandRanking]int) int {
+	key := strength[c.rank]
+	for i := 0; i < 5; i++ {
+		key <<= 4
+		if i < len(c.kickers) {
+			key |= c.kickers[i] + 1
+		}
+	}
+	return key
+}
+
+// Returns how strong the straight made by a mask of five ranks is, if the
+// ranks make a straight
+func (e *evaluator) straightStrength(mask uint32) (int, bool) {
+	for i, straight := range e.straights {
+		if mask == straight {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Creates an evaluator for hand rankings given from weakest to strongest, and
+// straights given from lowest to highest
+func newEvaluator(order []HandRanking, straights []uint32) *evaluator {
+	e := &evaluator{
+		straights: straights,
+		products:  make(map[uint32]HandScore),
+	}
+
+	var classes []handClass
+
+	// Hands with five distinct ranks are either straights or high cards, and
+	// have a matching flush variant
+	for mask := uint32(0); mask < 1<<13; mask++ {
+		if bits.OnesCount32(mask) != 5 {
+			continue
+		}
+		if strength, ok := e.straightStrength(mask); ok {
+			classes = append(classes,
+				handClass{rank: Straight, kickers: []int{strength}, mask: mask},
+				handClass{rank: StraightFlush, kickers: []int{strength}, flush: true, mask: mask},
+			)
+			continue
+		}
+		var kickers []int
+		for r := 12; r >= 0; r-- {
+			if mask&(1<<r) != 0 {
+				kickers = append(kickers, r)
+			}
+		}
+		classes = append(classes,
+			handClass{rank: HighCard, kickers: kickers, mask: mask},
+			handClass{rank: Flush, kickers: kickers, flush: true, mask: mask},
+		)
+	}
+
+	// Every other hand contains duplicate ranks, so enumerate how many of
+	// each rank the hand holds
+	var counts [13]int
+	var addPaired func(rank, remaining int)
+	addPaired = func(rank, remaining int) {
+		if remaining == 0 {
+			if class, ok := pairedClass(counts); ok {
+				classes = append(classes, class)
+			}
+			return
+		}
+		if rank < 0 {
+			return
+		}
+		for n := util.Min(remaining, 4); n >= 0; n-- {
+			counts[rank] = n
+			addPaired(rank-1, remaining-n)
+		}
+		counts[rank] = 0
+	}
+	addPaired(12, 5)
+
+	strength := make(map[HandRanking]int)
+	for i, rank := range order {
+		strength[rank] = i
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].key(strength) < classes[j].key(strength)
+	})
+
+	// Scores start at one so that the zero value can mean "no hand"
+	e.rankings = make([]HandRanking, len(classes)+1)
+	for i, class := range classes {
+		score := HandScore(i + 1)
+		e.rankings[score] = class.rank
+		switch {
+		case class.flush:
+			e.flushes[class.mask] = score
+		case class.product != 0:
+			e.products[class.product] = score
+		default:
+			e.unique5[class.mask] = score
+		}
+	}
+
+	return e
+}
+
+// Builds the hand class for a set of rank counts that contains duplicates
+func pairedClass(counts [13]int) (handClass, bool) {
+	var groups [][2]int // (count, rank) pairs
+	product := uint32(1)
+	for r := 12; r >= 0; r-- {
+		if counts[r] > 0 {
+			groups = append(groups, [2]int{counts[r], r})
+		}
+		for i := 0; i < counts[r]; i++ {
+			product *= rankPrimes[r]
+		}
+	}
+	if len(groups) == 5 {
+		return handClass{}, false
+	}
+
+	// Larger groups are more significant, then higher ranks
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i][0] > groups[j][0]
+	})
+	kickers := make([]int, len(groups))
+	for i, group := range groups {
+		kickers[i] = group[1]
+	}
+
+	var rank HandRanking
+	switch {
+	case groups[0][0] == 4:
+		rank = FourOfKind
+	case groups[0][0] == 3 && groups[1][0] == 2:
+		rank = FullHouse
+	case groups[0][0] == 3:
+		rank = ThreeOfKind
+	case groups[1][0] == 2:
+		rank = TwoPair
+	default:
+		rank = Pair
+	}
+
+	return handClass{rank: rank, kickers: kickers, product: product}, true
+}
+
+// Scores five encoded cards
+func (e *evaluator) eval5(a, b, c, d, f uint32) HandScore {
+	mask := (a | b | c | d | f) >> 16
+	if a&b&c&d&f&0xF000 != 0 {
+		return e.flushes[mask]
+	}
+	if score := e.unique5[mask]; score != 0 {
+		return score
+	}
+	return e.products[(a&0xFF)*(b&0xFF)*(c&0xFF)*(d&0xFF)*(f&0xFF)]
+}
+
+// The most cards that can be scored straight from the lookup tables. Up to
+// seven cards, a hand with a flush can't also hold a full house or quads, so
+// the best hand is either the best flush or the best hand ignoring suits
+const maxDirectCards = 7
+
+// Builds the tables for scoring six and seven cards without trying each
+// five-card subset
+func (e *evaluator) buildDirect() {
+	for mask := uint32(0); mask < 1<<13; mask++ {
+		n := bits.OnesCount32(mask)
+		if n < 5 || n > maxDirectCards {
+			continue
+		}
+		var ranks []uint32
+		for r := 0; r < 13; r++ {
+			if mask&(1<<r) != 0 {
+				ranks = append(ranks, 1<<r)
+			}
+		}
+		for _, combo := range combos[n][5] {
+			sub := ranks[combo[0]] | ranks[combo[1]] | ranks[combo[2]] | ranks[combo[3]] | ranks[combo[4]]
+			if score := e.flushes[sub]; score > e.bestFlushes[mask] {
+				e.bestFlushes[mask] = score
+			}
+		}
+	}
+
+	// Enumerate how many of each rank the cards hold, scoring them as if
+	// they were unsuited
+	e.bestProducts = make(map[uint64]HandScore)
+	var counts [13]int
+	var addCounts func(rank, remaining int)
+	addCounts = func(rank, remaining int) {
+		if remaining == 0 {
+			var codes []uint32
+			product := uint64(1)
+			for r, count := range counts {
+				for i := 0; i < count; i++ {
+					codes = append(codes, 1<<(16+r)|uint32(r)<<8|rankPrimes[r])
+					product *= uint64(rankPrimes[r])
+				}
+			}
+			e.bestProducts[product], _ = e.bestSubset(codes)
+			return
+		}
+		if rank < 0 {
+			return
+		}
+		for n := util.Min(remaining, 4); n >= 0; n-- {
+			counts[rank] = n
+			addCounts(rank-1, remaining-n)
+		}
+		counts[rank] = 0
+	}
+	for n := 6; n <= maxDirectCards; n++ {
+		addCounts(12, n)
+	}
+}
+
+// Scores the best five-card hand out of five to seven encoded cards straight
+// from the lookup tables
+func (e *evaluator) score(codes []uint32) HandScore {
+	if len(codes) == 5 {
+		return e.eval5(codes[0], codes[1], codes[2], codes[3], codes[4])
+	}
+	e.direct.Do(e.buildDirect)
+
+	var suits [4]uint32
+	product := uint64(1)
+	for _, code := range codes {
+		suits[bits.TrailingZeros32(code>>12&0xF)] |= code >> 16
+		product *= uint64(code & 0xFF)
+	}
+	for _, mask := range suits {
+		if bits.OnesCount32(mask) >= 5 {
+			return e.bestFlushes[mask]
+		}
+	}
+	return e.bestProducts[product]
+}
+
+// Returns the best score that can be made out of the encoded cards, along
+// with the indices of the five cards that make it. Hands of up to seven
+// cards are scored from the lookup tables, so finding the cards can stop at
+// the first subset with that score
+func (e *evaluator) best(codes []uint32) (HandScore, []int) {
+	if len(codes) > maxDirectCards {
+		return e.bestSubset(codes)
+	}
+	best := e.score(codes)
+	for _, combo := range combos[len(codes)][5] {
+		if e.eval5(codes[combo[0]], codes[combo[1]], codes[combo[2]], codes[combo[3]], codes[combo[4]]) == best {
+			return best, combo
+		}
+	}
+	return best, nil
+}
+
+// Returns the best score out of every five-card subset of the encoded cards,
+// along with the indices of the cards that make it
+func (e *evaluator) bestSubset(codes []uint32) (HandScore, []int) {
+	var best HandScore
+	var bestCombo []int
+	for _, combo := range combos[len(codes)][5] {
+		score := e.eval5(codes[combo[0]], codes[combo[1]], codes[combo[2]], codes[combo[3]], codes[combo[4]])
+		if score > best {
+			best = score
+			bestCombo = combo
+		}
+	}
+	return best, bestCombo
+}
+
+// Returns the worst score out of every five-card subset of the encoded cards,
+// along with the indices of the cards that make it
+func (e *evaluator) worst(codes []uint32) (HandScore, []int) {
+	var worst HandScore
+	var worstCombo []int
+	for _, combo := range combos[len(codes)][5] {
+		score := e.eval5(codes[combo[0]], codes[combo[1]], codes[combo[2]], codes[combo[3]], codes[combo[4]])
+		if worstCombo == nil || score < worst {
+			worst = score
+			worstCombo = combo
+		}
+	}
+	return worst, worstCombo
+}
+
+// Returns the score of the strongest possible hand
+func (e *evaluator) maxScore() HandScore {
+	return HandScore(len(e.rankings) - 1)
+}
+
+// Returns the hand ranking of a score
+func (e *evaluator) ranking(score HandScore) HandRanking {
+	return e.rankings[score]
+}
+
+// EvaluateCards scores the best five-card hand that can be made out of the
+// cards. Up to seven cards are scored straight from the lookup tables, and
+// larger hands by scoring every five-card subset. It returns an error unless
+// there are between 5 and 10 cards
+func EvaluateCards(cards []Card) (HandScore, error) {
+	if err := checkHandSize(len(cards)); err != nil {
+		return 0, err
+	}
+	codes := encodeCards(cards)
+	if len(codes) > maxDirectCards {
+		score, _ := standardEvaluator.bestSubset(codes)
+		return score, nil
+	}
+	return standardEvaluator.score(codes), nil
+}
@@ -0,0 +1,220 @@
+package Bot
+
+import (
+	"math/rand"
+	"testing"
+
+	"go-poker-bot/Bot/util"
+)
+
+func standardDeck() []Card {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	cards := make([]Card, 0, len(suits)*len(ranks))
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			cards = append(cards, Card{Suit: suit, Rank: rank})
+		}
+	}
+	return cards
+}
+
+// The number of distinct five-card hands of each ranking out of a 52-card deck
+func TestEvaluatorRankingCounts(t *testing.T) {
+	expected := map[HandRanking]int{
+		HighCard:      1302540,
+		Pair:          1098240,
+		TwoPair:       123552,
+		ThreeOfKind:   54912,
+		Straight:      10200,
+		Flush:         5108,
+		FullHouse:     3744,
+		FourOfKind:    624,
+		StraightFlush: 40,
+	}
+
+	codes := encodeCards(standardDeck())
+	counts := make(map[HandRanking]int)
+	scores := make(map[HandScore]struct{})
+	for c := range util.Combinations(codes, 5) {
+		score := standardEvaluator.eval5(c[0], c[1], c[2], c[3], c[4])
+		if score == 0 {
+			t.Fatalf("combination %v has no score", c)
+		}
+		counts[standardEvaluator.ranking(score)]++
+		scores[score] = struct{}{}
+	}
+
+	for rank, count := range expected {
+		if counts[rank] != count {
+			t.Errorf("ranking %d: got %d hands, expected %d", rank, counts[rank], count)
+		}
+	}
+	if len(scores) != 7462 {
+		t.Errorf("got %d distinct scores, expected 7462", len(scores))
+	}
+}
+
+// The number of distinct five-card hands of some rankings out of the 36-card
+// short deck
+func TestShortDeckRankingCounts(t *testing.T) {
+	expected := map[HandRanking]int{
+		Straight:      6120,
+		Flush:         480,
+		FourOfKind:    288,
+		StraightFlush: 24,
+	}
+
+	codes := encodeCards(NewShortDeckHoldem().Deck.AllCards())
+	counts := make(map[HandRanking]int)
+	for c := range util.Combinations(codes, 5) {
+		score := shortDeckEvaluator.eval5(c[0], c[1], c[2], c[3], c[4])
+		counts[shortDeckEvaluator.ranking(score)]++
+	}
+
+	for rank, count := range expected {
+		if counts[rank] != count {
+			t.Errorf("ranking %d: got %d hands, expected %d", rank, counts[rank], count)
+		}
+	}
+}
+
+func TestEvaluateCardsHandSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		cards   int
+		wantErr bool
+	}{
+		{name: "Too Few", cards: 4, wantErr: true},
+		{name: "Five", cards: 5, wantErr: false},
+		{name: "Seven", cards: 7, wantErr: false},
+		{name: "Ten", cards: 10, wantErr: false},
+		{name: "Too Many", cards: 11, wantErr: true},
+	}
+
+	deck := standardDeck()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := EvaluateCards(deck[:tt.cards])
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected an error to be %t, got %v", tt.wantErr, err)
+			}
+			if err == nil && score == 0 {
+				t.Errorf("expected %d cards to have a score", tt.cards)
+			}
+		})
+	}
+}
+
+// Scoring six and seven cards straight from the tables agrees with trying
+// every five-card subset
+func TestDirectScoring(t *testing.T) {
+	tests := []struct {
+		name string
+		e    *evaluator
+		deck []Card
+	}{
+		{name: "Standard", e: standardEvaluator, deck: standardDeck()},
+		{name: "Short Deck", e: shortDeckEvaluator, deck: NewShortDeckHoldem().Deck.AllCards()},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20000; i++ {
+				r.Shuffle(len(tt.deck), func(i, j int) {
+					tt.deck[i], tt.deck[j] = tt.deck[j], tt.deck[i]
+				})
+				codes := encodeCards(tt.deck[:5+i%3])
+				want, _ := tt.e.bestSubset(codes)
+				score, combo := tt.e.best(codes)
+				if score != want || len(combo) != 5 {
+					t.Fatalf("%v: expected a score of %d, got %d from %v", tt.deck[:len(codes)], want, score, combo)
+				}
+			}
+		})
+	}
+}
+
+// Calls a best hand function, failing the test if it returns an error
+func mustBestHand(t *testing.T, bestHand BestHandFunc, community []Card, hole []Card) Hand {
+	t.Helper()
+	hand, err := bestHand(community, hole)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return hand
+}
+
+// The best hand found by scoring every combination with NewHand, which is
+// what the evaluator replaces
+func combinationBestHand(community []Card, hole []Card) Hand {
+	allCards := append(append([]Card(nil), community...), hole...)
+
+	var best Hand
+	for handCards := range util.Combinations(allCards, 5) {
+		hand := NewHand(handCards)
+		if best.Rank == 0 || best.Less(hand) {
+			best = hand
+		}
+	}
+	return best
+}
+
+func randomHoldemHands(n int) [][]Card {
+	r := rand.New(rand.NewSource(1))
+	deck := standardDeck()
+
+	hands := make([][]Card, n)
+	for i := range hands {
+		r.Shuffle(len(deck), func(i, j int) {
+			deck[i], deck[j] = deck[j], deck[i]
+		})
+		hands[i] = append([]Card(nil), deck[:7]...)
+	}
+	return hands
+}
+
+func BenchmarkTexasHoldemBestHand(b *testing.B) {
+	hands := randomHoldemHands(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cards := hands[i%len(hands)]
+		TexasHoldemBestHand(cards[:5], cards[5:])
+	}
+}
+
+func BenchmarkCombinationBestHand(b *testing.B) {
+	hands := randomHoldemHands(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cards := hands[i%len(hands)]
+		combinationBestHand(cards[:5], cards[5:])
+	}
+}
+
+func BenchmarkEvaluateCards(b *testing.B) {
+	hands := randomHoldemHands(1000)
+	codes := make([][]uint32, len(hands))
+	for i, hand := range hands {
+		codes[i] = encodeCards(hand)
+	}
+	standardEvaluator.direct.Do(standardEvaluator.buildDirect)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		standardEvaluator.score(codes[i%len(codes)])
+	}
+}
+
+func BenchmarkEvaluateSubsets(b *testing.B) {
+	hands := randomHoldemHands(1000)
+	codes := make([][]uint32, len(hands))
+	for i, hand := range hands {
+		codes[i] = encodeCards(hand)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		standardEvaluator.bestSubset(codes[i%len(codes)])
+	}
+}
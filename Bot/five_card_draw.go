@@ -0,0 +1,26 @@
+package Bot
+
+// NewFiveCardDraw creates a new Five-Card Draw game, where each player is
+// dealt five cards and gets one chance to replace some of them
+func NewFiveCardDraw() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	return PokerType{
+		GameType: FiveCardDrawType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: FiveCardDrawBestHand,
+		Streets: []Street{
+			{Name: "the hole cards", Down: 5},
+			{Name: "the draw", Draw: true},
+		},
+		String: func() string {
+			return "Five-Card Draw"
+		},
+	}
+}
+
+// Returns the hand made by a player's five cards
+func FiveCardDrawBestHand(community []Card, hole []Card) (Hand, error) {
+	return standardEvaluator.bestHoldemHand(community, hole)
+}
@@ -0,0 +1,88 @@
+package Bot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func newTestGame(gameType string, players int) *Game {
+	game := NewGame()
+	game.ChangeGameType(gameType)
+	game.StartNewGame()
+	for i := 0; i < players; i++ {
+		name := string(rune('A' + i))
+		game.Players = append(game.Players, &Player{User: &discordgo.User{ID: name}, Name: name, Balance: 100, Seat: i})
+	}
+	return game
+}
+
+func TestFiveCardDraw(t *testing.T) {
+	game := newTestGame("draw", 3)
+	game.DealHands()
+
+	for _, player := range game.Players {
+		if len(player.Cards) != 5 {
+			t.Fatalf("%s was dealt %d cards", player.Name, len(player.Cards))
+		}
+	}
+
+	// Everyone calls the big blind, then the big blind checks
+	game.Call()
+	game.Call()
+	game.Check()
+	if game.GetState() != Drawing {
+		t.Fatalf("expected the draw to start after the first betting round")
+	}
+
+	// The small blind draws first
+	first := game.DrawOrder[0]
+	if first != game.Players[1] {
+		t.Errorf("expected %s to draw first, got %s", game.Players[1].Name, first.Name)
+	}
+
+	kept := first.Cards[1]
+	if messages := game.Draw([]int{1, 1}); game.DrawOrder[0] != first {
+		t.Errorf("expected discarding the same card twice to be rejected, got %v", messages)
+	}
+	if messages := game.Draw([]int{6}); game.DrawOrder[0] != first {
+		t.Errorf("expected an out of range position to be rejected, got %v", messages)
+	}
+
+	discarded := []Card{first.Cards[0], first.Cards[2]}
+	game.Draw([]int{1, 3})
+	if first.Cards[1] != kept {
+		t.Errorf("expected %s to be kept", kept)
+	}
+	for _, card := range discarded {
+		for _, held := range first.Cards {
+			if held == card {
+				t.Errorf("expected %s to be discarded", card)
+			}
+		}
+	}
+
+	game.Draw(nil)
+	game.Draw([]int{1, 2, 3, 4, 5})
+	if game.GetState() != HandsDealt {
+		t.Fatalf("expected betting to resume after everyone drew")
+	}
+
+	seen := make(map[Card]bool)
+	for _, player := range game.Players {
+		for _, card := range player.Cards {
+			if seen[card] {
+				t.Errorf("%s was dealt twice", card)
+			}
+			seen[card] = true
+		}
+	}
+
+	// Checking around ends the hand
+	game.Check()
+	game.Check()
+	game.Check()
+	if game.GetState() != NoHands {
+		t.Errorf("expected the hand to be over")
+	}
+}
@@ -2,11 +2,14 @@ package Bot
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"go-poker-bot/Bot/util"
+
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -18,9 +21,9 @@ const (
 	Waiting
 	NoHands
 	HandsDealt
-	FlopDealt
-	TurnDealt
-	RiverDealt
+	Drawing
+	Discarding
+	ChoosingRunouts
 )
 
 // GameOptions represents configurable options for the game
@@ -30,6 +33,21 @@ type GameOptions struct {
 	MinBuyIn   int
 	MaxBuyIn   int
 	RaiseDelay int // minutes before blinds double, 0 means off
+	Limit      BettingStructure
+	// The ante paid by every player each hand, 0 means off
+	Ante int
+	// The ante paid by the big blind for the whole table, 0 means off
+	BigBlindAnte int
+	// Whether the player under the gun can straddle
+	Straddle bool
+	// Whether the dealer can straddle too, when straddles are allowed
+	Mississippi bool
+	// Seconds each player has to act, 0 means off
+	Timer int
+	// Extra seconds each player can use once their time runs out
+	TimeBank int
+	// Orbits a player can sit out before being removed, 0 means never
+	SitOutOrbits int
 }
 
 // Game represents the state of a poker game
@@ -44,6 +62,26 @@ type Game struct {
 	Deck Deck
 	// The community cards
 	Community []Card
+	// The index of the street currently being bet on
+	Street int
+	// Whether face-down cards have been dealt that players haven't been told
+	// about yet
+	NewHoleCards bool
+	// The players still waiting to draw, in order
+	DrawOrder []*Player
+	// The players who still have to discard before the street is dealt
+	AwaitingDiscard map[*Player]bool
+	// The number of bets and raises made in the current betting round
+	Bets int
+	// The players who have agreed to run the rest of the board twice
+	RunTwice map[*Player]bool
+	// The player who made the last bet or raise in the current betting round
+	Aggressor *Player
+	// The players from the last hand whose cards weren't shown, who can still
+	// show them
+	Unshown []*Player
+	// The rest of the board from the last hand, if it ended before being dealt
+	Rabbit []Card
 	// The players in the game
 	Players []*Player
 	// The current state of the game
@@ -54,8 +92,33 @@ type Game struct {
 	FirstBettor int
 	// The index of the player whose turn it is
 	TurnIndex int
+	// Counts every turn taken, so the action clock can tell when the turn has
+	// moved on
+	Turn int
+	// The seconds that have passed on the current turn's clock
+	TurnTime int
+	// The turn that the action clock was last started for
+	clockTurn int
 	// The players currently in the hand
 	InHand []*Player
+	// The player who has straddled for the next hand, if anyone
+	Straddler *Player
+	// The seats of the button and blinds in the last hand, or nil before the
+	// first hand is dealt
+	LastPositions *Positions
+	// The players who have left the game, with the balances they left with
+	Left []*Player
+	// The ledger that buy-ins and cash-outs are recorded in, if any
+	Ledger *Ledger
+	// The guild the game is played in, which the ledger is kept by
+	GuildID string
+	// The channel the game is played in, which names the table in hand
+	// histories
+	ChannelID string
+	// The history of the hand being played, or nil if it isn't being recorded
+	History *HandHistory
+	// The histories of finished hands that haven't been saved yet
+	finishedHands []string
 	// Game options
 	Options GameOptions
 	// The last time that the blinds were automatically raised
@@ -82,6 +145,7 @@ func NewGame() *Game {
 			MinBuyIn:   50,
 			MaxBuyIn:   1000,
 			RaiseDelay: 0, // blinds don't raise by default
+			Limit:      gt.Limit,
 		},
 	}
 }
@@ -93,6 +157,10 @@ func (g *Game) StartNewGame() {
 	g.Community = make([]Card, 0)
 	g.TurnIndex = -1
 	g.LastRaise = nil
+	g.Straddler = nil
+	g.LastPositions = nil
+	g.DealerIndex = 0
+	g.Left = nil
 }
 
 func (g *Game) GetState() GameState {
@@ -132,12 +200,18 @@ func (g *Game) IsPlayer(user *discordgo.User) bool {
 	return false
 }
 
+// AddPlayer seats a new player in the next seat. Once the game is under way,
+// new players wait for the big blind before being dealt in
 func (g *Game) AddPlayer(user *discordgo.User, name string) {
 	g.Players = append(g.Players, &Player{
-		User:    user,
-		Balance: g.Options.MinBuyIn,
-		Name:    name,
+		User:     user,
+		Balance:  g.Options.MinBuyIn,
+		Name:     name,
+		Seat:     g.nextSeat(),
+		Waiting:  g.LastPositions != nil,
+		TimeBank: g.Options.TimeBank,
 	})
+	g.recordLedger(g.Players[len(g.Players)-1], -g.Options.MinBuyIn, "Joined a game")
 }
 
 func (g *Game) BuyIn(user *discordgo.User, amount int, newPlayer bool) []string {
@@ -151,9 +225,15 @@ func (g *Game) BuyIn(user *discordgo.User, amount int, newPlayer bool) []string
 
 	player := g.GetPlayer(user)
 	player.Balance += amount
+	g.recordLedger(player, -amount, "Bought in")
 
 	if newPlayer {
-		return []string{fmt.Sprintf("You've bought in for $%d.", amount)}
+		messages := []string{fmt.Sprintf("You've bought in for $%d.", amount)}
+		if player.Waiting && g.Type.BringIn == nil {
+			live, dead := g.catchUpBlinds(player)
+			messages = append(messages, fmt.Sprintf("You'll be dealt in when the big blind reaches you, or message !post to be dealt in on the next hand for $%d.", live+dead))
+		}
+		return messages
 	} else {
 		return []string{fmt.Sprintf("Increased your balance by $%d. You now have $%d.", amount, player.Balance)}
 	}
@@ -170,7 +250,8 @@ func (g *Game) StatusBetweenRounds() []string {
 	return messages
 }
 
-func (g *Game) PayBlinds() []string {
+// Doubles the blinds if enough time has passed since they were last raised
+func (g *Game) RaiseBlinds() []string {
 	messages := []string{}
 
 	now := time.Now()
@@ -185,76 +266,297 @@ func (g *Game) PayBlinds() []string {
 		messages = append(messages, "**Blinds are being doubled this round!**")
 		g.Options.SmallBlind *= 2
 		g.Options.BigBlind *= 2
+		g.Options.Ante *= 2
+		g.Options.BigBlindAnte *= 2
 		g.LastRaise = &now
 	}
 
-	smallBlind := g.Options.SmallBlind
-	bigBlind := g.Options.BigBlind
+	return messages
+}
+
+// Returns the players who pay the small and big blinds this hand. The small
+// blind is nil when it's dead
+func (g *Game) blindPlayers() (*Player, *Player) {
+	return g.inHandInSeat(g.LastPositions.SmallBlind), g.inHandInSeat(g.LastPositions.BigBlind)
+}
 
-	var smallPlayer, bigPlayer *Player
+// Collects the antes before the blinds are paid. A big blind ante is paid by
+// the big blind alone, and comes after their blind if they can't cover both
+func (g *Game) PayAntes() []string {
+	messages := []string{}
 
-	if len(g.InHand) > 2 {
-		smallPlayer = g.InHand[(g.DealerIndex+1)%len(g.InHand)]
-		bigPlayer = g.InHand[(g.DealerIndex+2)%len(g.InHand)]
-		// The first player to bet pre-flop is the player to the left of the big blind
-		g.TurnIndex = (g.DealerIndex + 3) % len(g.InHand)
-		// The first player to bet post-flop is the first player to the left of the dealer
-		g.FirstBettor = (g.DealerIndex + 1) % len(g.Players)
-	} else {
-		// In heads-up games, who plays the blinds is different, with the
-		// dealer playing the small blind and the other player paying the big
-		smallPlayer = g.Players[g.DealerIndex]
-		bigPlayer = g.Players[(g.DealerIndex+1)%len(g.Players)]
+	if g.Options.Ante > 0 {
+		for _, player := range g.InHand {
+			g.History.Post(player, "the ante", util.Min(g.Options.Ante, player.Balance))
+		}
+		g.PotManager.PayAntes(g.InHand, g.Options.Ante)
+		messages = append(messages, fmt.Sprintf("Everyone has paid an ante of $%d.", g.Options.Ante))
+	}
 
-		// Dealer goes first pre-flop, the other player goes first afterwards
-		g.TurnIndex = g.DealerIndex
-		g.FirstBettor = (g.DealerIndex + 1) % len(g.Players)
+	if g.Options.BigBlindAnte > 0 && g.Type.BringIn == nil {
+		_, bigPlayer := g.blindPlayers()
+		ante := util.Min(g.Options.BigBlindAnte, util.Max(0, bigPlayer.Balance-g.Options.BigBlind))
+		if ante > 0 {
+			g.PotManager.PayDead(bigPlayer, ante)
+			g.History.Post(bigPlayer, "the ante", ante)
+			messages = append(messages, fmt.Sprintf("%s has paid the big blind ante of $%d.", bigPlayer.Name, ante))
+		}
 	}
 
-	messages = append(messages, fmt.Sprintf("%s has paid the small blind of $%d.", smallPlayer.Name, smallBlind))
+	return messages
+}
 
-	if g.PotManager.PayBlind(smallPlayer, smallBlind) {
-		messages = append(messages, fmt.Sprintf("%s is all in!", smallPlayer.Name))
-		g.LeaveHand(smallPlayer)
+func (g *Game) PayBlinds() []string {
+	messages := []string{}
+
+	smallBlind := g.Options.SmallBlind
+	bigBlind := g.Options.BigBlind
+
+	smallPlayer, bigPlayer := g.blindPlayers()
+
+	// The first player to bet pre-flop is the player to the left of the big
+	// blind, which is the dealer in heads-up games
+	g.TurnIndex = (slices.Index(g.InHand, bigPlayer) + 1) % len(g.InHand)
+	// The first player to bet post-flop is the first player to the left of the dealer
+	g.FirstBettor = g.firstLeftOfButton()
+
+	if smallPlayer == nil {
+		messages = append(messages, "The small blind is dead this hand.")
+	} else {
+		messages = append(messages, fmt.Sprintf("%s has paid the small blind of $%d.", smallPlayer.Name, smallBlind))
+
+		allIn := g.PotManager.PayBlind(smallPlayer, smallBlind)
+		g.History.Post(smallPlayer, "small blind", smallPlayer.CurBet)
+		if allIn {
+			messages = append(messages, fmt.Sprintf("%s is all in!", smallPlayer.Name))
+			g.LeaveHand(smallPlayer)
+		}
 	}
 
 	messages = append(messages, fmt.Sprintf("%s has paid the big blind of $%d.", bigPlayer.Name, bigBlind))
 
-	if g.PotManager.PayBlind(bigPlayer, bigBlind) {
+	// Players waiting for the big blind are dealt in once they pay it
+	bigPlayer.ReturnToPlay()
+	allIn := g.PotManager.PayBlind(bigPlayer, bigBlind)
+	g.History.Post(bigPlayer, "big blind", bigPlayer.CurBet)
+	if allIn {
 		messages = append(messages, fmt.Sprintf("%s is all in!", bigPlayer.Name))
 		g.LeaveHand(bigPlayer)
 	}
 
+	return append(messages, g.PayPosts()...)
+}
+
+// Straddle has the user post a straddle of two big blinds on the next hand,
+// which makes them act last before the flop
+func (g *Game) Straddle(user *discordgo.User) []string {
+	if !g.Options.Straddle {
+		return []string{"Straddles aren't allowed at this table!"}
+	}
+	if g.Type.BringIn != nil {
+		return []string{"You can only straddle in games with blinds!"}
+	}
+	pos, ok := g.nextPositions()
+	big := g.playerInSeat(pos.BigBlind)
+	if !ok || g.countDealtIn(big) < 3 {
+		return []string{"You can't straddle heads-up!"}
+	}
+	if g.Straddler != nil {
+		return []string{fmt.Sprintf("%s has already straddled!", g.Straddler.Name)}
+	}
+
+	player := g.GetPlayer(user)
+	underTheGun := g.nextSeated(pos.BigBlind, func(p *Player) bool {
+		return g.dealtIn(p, big)
+	})
+	if player != underTheGun && (!g.Options.Mississippi || player.Seat != pos.Button) {
+		if g.Options.Mississippi {
+			return []string{"Only the player under the gun or the dealer can straddle!"}
+		}
+		return []string{"Only the player under the gun can straddle!"}
+	}
+
+	amount := 2 * g.Options.BigBlind
+	if player.Balance <= amount {
+		return []string{"You don't have enough money to straddle!"}
+	}
+
+	g.Straddler = player
+	return []string{fmt.Sprintf("%s will straddle for $%d on the next hand.", player.Name, amount)}
+}
+
+// Pays the straddle posted for this hand, if there is one, and has the player
+// to the left of the straddler act first
+func (g *Game) PayStraddle() []string {
+	player := g.Straddler
+	g.Straddler = nil
+	if player == nil {
+		return []string{}
+	}
+	index := slices.Index(g.InHand, player)
+	if index == -1 {
+		return []string{}
+	}
+
+	amount := 2 * g.Options.BigBlind
+	messages := []string{fmt.Sprintf("%s has straddled for $%d.", player.Name, amount)}
+	g.TurnIndex = (index + 1) % len(g.InHand)
+	// The straddle counts as a raise of the big blind
+	g.Bets++
+
+	allIn := g.PotManager.PayBlind(player, amount)
+	g.History.Post(player, "straddle", player.CurBet)
+	if allIn {
+		messages = append(messages, fmt.Sprintf("%s is all in!", player.Name))
+		g.LeaveHand(player)
+	}
+
 	return messages
 }
 
-func (g *Game) Showdown() []string {
+// Has the player with the worst face-up card pay the bring-in, and sets the
+// player to their left to act first
+func (g *Game) PayBringIn() []string {
+	doors := make([]Card, len(g.InHand))
+	for i, player := range g.InHand {
+		doors[i] = player.UpCards[0]
+	}
+
+	index := g.Type.BringIn(doors)
+	player := g.InHand[index]
+	g.TurnIndex = (index + 1) % len(g.InHand)
+	g.FirstBettor = g.firstLeftOfButton()
+
+	messages := []string{fmt.Sprintf("%s brings it in for $%d.", player.Name, g.Options.SmallBlind)}
+
+	allIn := g.PotManager.PayBlind(player, g.Options.SmallBlind)
+	g.History.BringIn(player, player.CurBet)
+	if allIn {
+		messages = append(messages, fmt.Sprintf("%s is all in!", player.Name))
+		g.LeaveHand(player)
+		g.TurnIndex = index % len(g.InHand)
+	}
+
+	return messages
+}
+
+// Returns the players still in the pot, in seat order
+func (g *Game) playersInPot() []*Player {
+	inPot := g.PotManager.InPot()
+	players := make([]*Player, 0, len(inPot))
+	for _, player := range g.SeatOrder() {
+		if _, ok := inPot[player]; ok {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// Deals a street's cards to the board and to every player still in the pot,
+// returning messages showing the cards dealt face-up
+func (g *Game) dealStreet(street Street) []string {
 	messages := []string{}
+	players := g.playersInPot()
+
+	perPlayer := street.Down + street.Up
+	if perPlayer > 0 && perPlayer*len(players) > g.Deck.Len() {
+		// If there aren't enough cards left for everyone, a single community
+		// card is dealt for all the players to share instead
+		street = Street{Name: street.Name, Community: 1}
+	}
+
+	for _, player := range players {
+		player.Cards = append(player.Cards, g.Deck.Deal(street.Down)...)
+		player.UpCards = append(player.UpCards, g.Deck.Deal(street.Up)...)
+	}
+	if street.Down > 0 {
+		g.NewHoleCards = true
+	}
+	g.Community = append(g.Community, g.Deck.Deal(street.Community)...)
 
-	for len(g.Community) < 5 {
-		g.Community = append(g.Community, g.Deck.Deal(1)...)
+	if street.Community > 0 {
+		messages = append(messages, g.PrintBoard())
+	}
+	if street.Up > 0 {
+		for _, player := range players {
+			messages = append(messages, fmt.Sprintf("%s is showing %s", player.Name, player.PrintUpCards()))
+		}
 	}
 
-	messages = append(messages, "We have reached the end of betting. "+
-		"All cards will be revealed.")
+	return messages
+}
 
+// Returns the community cards
+func (g *Game) PrintBoard() string {
 	communityStr := make([]string, len(g.Community))
 	for i, card := range g.Community {
 		communityStr[i] = card.String()
 	}
-	messages = append(messages, strings.Join(communityStr, "  "))
+	return strings.Join(communityStr, "  ")
+}
 
-	for player := range g.PotManager.InPot() {
-		messages = append(messages, fmt.Sprintf("%s's hand: %s", player.Name, player.PrintHand()))
+// Showdown deals out the rest of the hand once betting is over. When only
+// community cards are left to deal, everyone in the pot chooses whether to run
+// the board twice first
+func (g *Game) Showdown() []string {
+	if g.canRunTwice() {
+		// Wait until everyone has agreed before running it twice
+		if waiting := g.waitingToRunTwice(); len(waiting) > 0 {
+			// Everyone still to agree shares the action clock
+			if g.State != ChoosingRunouts {
+				g.startTurn()
+			}
+			g.State = ChoosingRunouts
+			return []string{fmt.Sprintf("Betting is over. Waiting on %s to agree to run it twice. "+
+				"Message !runtwice to agree or !runonce to deal the board once.", playerNames(waiting))}
+		}
+		g.State = HandsDealt
+		return g.revealHandsOn(g.runTwice())
 	}
 
-	winners := g.PotManager.GetWinners(g.Community, g.Type.BestHand)
+	// Deal out the rest of the streets, if betting ended early. Players who
+	// are all in still get to draw and discard
+	messages := []string{}
+	for g.Street+1 < len(g.Type.Streets) {
+		g.Street++
+		street := g.Type.Streets[g.Street]
+		if street.Discard > 0 {
+			return append(messages, g.StartDiscard()...)
+		}
+		if street.Draw {
+			messages = append(messages, fmt.Sprintf("Time for %s!", street.Name))
+			return append(messages, g.StartDraw()...)
+		}
+		g.dealStreet(street)
+		g.recordStreet()
+	}
+	return append(messages, g.revealHandsOn([][]Card{g.Community})...)
+}
 
-	for winner, winnings := range winners {
-		handName := g.Type.BestHand(g.Community, winner.Cards)
-		messages = append(messages, fmt.Sprintf("%s wins $%d with a %s.", winner.Name, winnings, handName))
-		winner.Balance += winnings
+// Reveals the hands and awards the pots on each of the boards, then moves on
+// to the next hand
+func (g *Game) revealHandsOn(boards [][]Card) []string {
+	messages := []string{"We have reached the end of betting. " +
+		"All cards will be revealed."}
+
+	messages = append(messages, g.revealHands(boards)...)
+
+	// Each board is played for an equal share of every pot
+	shares := g.PotManager.Split(len(boards))
+	if len(boards) > 1 && shares[0].Value() > shares[len(shares)-1].Value() {
+		messages = append(messages, "The odd chips from splitting the pot go to the first board.")
 	}
+	for i, board := range boards {
+		g.Community = board
+		if len(boards) > 1 {
+			messages = append(messages, fmt.Sprintf("**Board %d:**", i+1))
+		}
+		if len(g.Community) > 0 {
+			messages = append(messages, g.PrintBoard())
+		}
+		messages = append(messages, g.awardPots(shares[i])...)
+	}
+	g.finishHistory(g.PotManager.Value(), boards)
 
 	// Remove players that went all in and lost
 	i := 0
@@ -268,24 +570,81 @@ func (g *Game) Showdown() []string {
 			if len(g.Players) == 1 {
 				// There's only one player, so they win
 				messages = append(messages, fmt.Sprintf("%s wins the game! Congratulations!", g.Players[0].Name))
+				g.recordLedger(g.Players[0], g.Players[0].Balance, "Won the game")
 				g.State = NoGame
 				return messages
 			}
-			if i <= g.DealerIndex {
-				g.DealerIndex -= 1
-			}
 		}
 	}
 
 	// Go on to the next round
-	g.State = NoHands
-	g.NextDealer()
+	return append(messages, g.finishHand()...)
+}
 
-	return append(messages, g.StatusBetweenRounds()...)
+// Awards the pots to the best hands on the current board, returning messages
+// about who won what
+func (g *Game) awardPots(pm PotManager) []string {
+	messages := []string{}
+
+	winners, err := pm.GetWinners(g.Community, g.Type.BestHand, g.Type.LowHand, g.SeatOrder())
+	if err != nil {
+		messages = append(messages, fmt.Sprintf("Couldn't work out the winners (%v), so the pot is chopped.", err))
+		winners = pm.Chop(g.SeatOrder())
+	}
+
+	for winner, winnings := range winners {
+		if winnings.High > 0 {
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("%s gets $%d.", winner.Name, winnings.High))
+			} else {
+				handName, _ := g.Type.BestHand(g.Community, winner.AllCards())
+				messages = append(messages, fmt.Sprintf("%s wins $%d with a %s.", winner.Name, winnings.High, handName))
+			}
+		}
+		if winnings.Low > 0 {
+			handName, _ := g.Type.LowHand(g.Community, winner.AllCards())
+			messages = append(messages, fmt.Sprintf("%s wins $%d for low with %s.", winner.Name, winnings.Low, handName))
+		}
+		winner.Balance += winnings.Total()
+	}
+
+	for _, player := range g.SeatOrder() {
+		if winnings, ok := winners[player]; ok {
+			g.History.Collect(player, winnings.Total())
+		}
+	}
+
+	for _, player := range g.SeatOrder() {
+		if winnings, ok := winners[player]; ok && winnings.OddChips > 0 {
+			if winnings.OddChips == 1 {
+				messages = append(messages, fmt.Sprintf("%s receives the odd chip.", player.Name))
+			} else {
+				messages = append(messages, fmt.Sprintf("%s receives %d odd chips.", player.Name, winnings.OddChips))
+			}
+		}
+	}
+
+	return messages
 }
 
+// SeatOrder returns the players in seat order, starting from the first seat
+// to the left of the dealer
+func (g *Game) SeatOrder() []*Player {
+	order := make([]*Player, 0, len(g.Players))
+	for i := 1; i <= len(g.Players); i++ {
+		order = append(order, g.Players[(g.DealerIndex+i)%len(g.Players)])
+	}
+	return order
+}
+
+// NextDealer moves the dealer on to whoever sits on or just before the button
+// for the next hand
 func (g *Game) NextDealer() {
-	g.DealerIndex = (g.DealerIndex + 1) % len(g.Players)
+	if pos, ok := g.nextPositions(); ok {
+		g.DealerIndex = g.seatIndex(pos.Button)
+	} else {
+		g.DealerIndex %= len(g.Players)
+	}
 }
 
 func (g *Game) Fold() []string {
@@ -295,6 +654,7 @@ func (g *Game) Fold() []string {
 		messages = append(messages, fmt.Sprintf("%s has folded.", g.GetCurrentPlayer().Name))
 	}
 
+	g.History.Fold(g.GetCurrentPlayer())
 	g.PotManager.HandleFold(g.GetCurrentPlayer())
 	g.LeaveHand(g.GetCurrentPlayer())
 
@@ -308,9 +668,12 @@ func (g *Game) Fold() []string {
 		}
 		messages = append(messages, fmt.Sprintf("%s wins $%d!", winner.Name, g.PotManager.Value()))
 		winner.Balance += g.PotManager.Value()
-		g.State = NoHands
-		g.NextDealer()
-		return append(messages, g.StatusBetweenRounds()...)
+		g.recordFoldWin(winner)
+		// The winner can choose to show their hand, and anyone can see the
+		// rest of the board
+		g.Unshown = []*Player{winner}
+		g.Rabbit = g.huntRabbit()
+		return append(messages, g.finishHand()...)
 	}
 
 	// If there's still betting to do, go on to the next turn
@@ -326,7 +689,9 @@ func (g *Game) Fold() []string {
 func (g *Game) Call() []string {
 	messages := []string{}
 
+	before := g.GetCurrentPlayer().CurBet
 	g.PotManager.HandleCall(g.GetCurrentPlayer())
+	g.History.Call(g.GetCurrentPlayer(), g.GetCurrentPlayer().CurBet-before)
 
 	if g.Verbose {
 		messages = append(messages, fmt.Sprintf("%s calls.", g.GetCurrentPlayer().Name))
@@ -344,13 +709,37 @@ func (g *Game) Call() []string {
 func (g *Game) Raise(amount int) []string {
 	messages := []string{}
 
-	maxBet := g.Type.MaxBet(g.GetCurrentPlayer(), &g.PotManager)
+	if amount <= 0 {
+		return []string{"You have to raise by more than $0!"}
+	}
+	if g.Options.Limit.Capped(g.Bets) {
+		return []string{"Betting is capped for this round - you can only call or fold!"}
+	}
+	if !g.PotManager.CanRaise(g.GetCurrentPlayer()) {
+		return []string{"Nobody has made a full raise since you acted, so you can only call or fold!"}
+	}
 
-	if amount > maxBet {
-		amount = maxBet
+	minRaise, maxRaise := g.RaiseLimits()
+	if maxRaise <= 0 {
+		return []string{"You don't have enough chips to raise!"}
 	}
+	if g.Options.Limit == FixedLimit || amount > maxRaise {
+		amount = maxRaise
+	}
+	if amount < minRaise {
+		return []string{fmt.Sprintf("The minimum raise is $%d, unless you go all in!", minRaise)}
+	}
+	completing := g.completing()
+	g.Bets++
 
+	from := g.PotManager.CurBet()
 	g.PotManager.HandleRaise(g.GetCurrentPlayer(), amount)
+	if completing {
+		g.History.Complete(g.GetCurrentPlayer())
+	} else {
+		g.History.Raise(g.GetCurrentPlayer(), from)
+	}
+	g.Aggressor = g.GetCurrentPlayer()
 
 	if g.Verbose {
 		messages = append(messages, fmt.Sprintf("%s raises by $%d.", g.GetCurrentPlayer().Name, amount))
@@ -374,6 +763,8 @@ func (g *Game) Check() []string {
 	}
 
 	g.GetCurrentPlayer().PlacedBet = true
+	g.GetCurrentPlayer().ActedOn = g.PotManager.CurBet()
+	g.History.Check(g.GetCurrentPlayer())
 
 	if g.Verbose {
 		messages = append(messages, fmt.Sprintf("%s checks.", g.GetCurrentPlayer().Name))
@@ -382,12 +773,40 @@ func (g *Game) Check() []string {
 	return append(messages, g.NextTurn()...)
 }
 
+// RaiseLimits returns the smallest and largest amounts the current player can
+// raise by. A raise has to be at least as big as the last full bet or raise,
+// except that the first raise over a bring-in completes it to a full bet
+func (g *Game) RaiseLimits() (int, int) {
+	minRaise := util.Max(g.BetSize(), g.PotManager.LastRaise)
+	if g.completing() {
+		minRaise = g.BetSize() - g.PotManager.CurBet()
+	}
+	return g.Options.Limit.RaiseLimits(g.GetCurrentPlayer(), &g.PotManager, minRaise)
+}
+
+// Returns whether the current bet is a bring-in, so raising it completes it to
+// a full bet
+func (g *Game) completing() bool {
+	return g.Type.BringIn != nil && g.Bets == 0 && g.PotManager.CurBet() > 0 && g.PotManager.CurBet() < g.BetSize()
+}
+
+// BetSize returns the minimum bet, which in fixed-limit games is the size of
+// every bet and doubles halfway through the streets
+func (g *Game) BetSize() int {
+	if g.Options.Limit == FixedLimit && g.Street >= len(g.Type.Streets)/2 {
+		return 2 * g.Options.BigBlind
+	}
+	return g.Options.BigBlind
+}
+
+// AllIn raises as much as the betting structure allows, or calls if the
+// player can't raise
 func (g *Game) AllIn() []string {
-	if g.PotManager.CurBet() > g.Type.MaxBet(g.GetCurrentPlayer(), &g.PotManager) {
+	_, maxRaise := g.RaiseLimits()
+	if g.Options.Limit.Capped(g.Bets) || !g.PotManager.CanRaise(g.GetCurrentPlayer()) || maxRaise <= 0 {
 		return g.Call()
-	} else {
-		return g.Raise(g.Type.MaxBet(g.GetCurrentPlayer(), &g.PotManager) - g.PotManager.CurBet())
 	}
+	return g.Raise(maxRaise)
 }
 
 // Removes a player from being able to bet, if they folded or went all in
@@ -401,6 +820,9 @@ func (g *Game) LeaveHand(player *Player) {
 			if i < g.FirstBettor {
 				g.FirstBettor -= 1
 			}
+			if i < g.TurnIndex {
+				g.TurnIndex -= 1
+			}
 			if g.FirstBettor >= len(g.InHand) {
 				g.FirstBettor = 0
 			}
@@ -413,35 +835,207 @@ func (g *Game) LeaveHand(player *Player) {
 }
 
 func (g *Game) NextRound() []string {
-	messages := []string{}
-
-	switch g.State {
-	case HandsDealt:
-		messages = append(messages, "Dealing the flop:")
-		g.Community = append(g.Community, g.Deck.Deal(3)...)
-		g.State = FlopDealt
-	case FlopDealt:
-		messages = append(messages, "Dealing the turn:")
-		g.Community = append(g.Community, g.Deck.Deal(1)...)
-		g.State = TurnDealt
-	case TurnDealt:
-		messages = append(messages, "Dealing the river:")
-		g.Community = append(g.Community, g.Deck.Deal(1)...)
-		g.State = RiverDealt
-	case RiverDealt:
+	g.Street++
+	if g.Street >= len(g.Type.Streets) {
 		return g.Showdown()
 	}
 
-	communityStr := make([]string, len(g.Community))
-	for i, card := range g.Community {
-		communityStr[i] = card.String()
+	if g.Type.Streets[g.Street].Discard > 0 {
+		return g.StartDiscard()
 	}
-	messages = append(messages, strings.Join(communityStr, "  "))
+	return g.PlayStreet()
+}
+
+// PlayStreet draws or deals the current street, then starts its round of
+// betting
+func (g *Game) PlayStreet() []string {
+	street := g.Type.Streets[g.Street]
+	if street.Draw {
+		messages := []string{fmt.Sprintf("Time for %s!", street.Name)}
+		return append(messages, g.StartDraw()...)
+	}
+
+	messages := []string{fmt.Sprintf("Dealing %s:", street.Name)}
+	messages = append(messages, g.dealStreet(street)...)
+	g.recordStreet()
 
+	// If nobody can bet anymore, keep dealing
+	if g.PotManager.BettingOver() {
+		return append(messages, g.Showdown()...)
+	}
+
+	return append(messages, g.startBetting()...)
+}
+
+// Starts a new round of betting on the current street
+func (g *Game) startBetting() []string {
 	g.PotManager.NextRound()
-	g.TurnIndex = g.FirstBettor
+	g.TurnIndex = g.firstToAct()
+	g.Bets = 0
+	g.Aggressor = nil
+	g.startTurn()
+	return g.CurOptions()
+}
+
+// StartDiscard has every player in the pot throw away cards before the
+// current street is dealt
+func (g *Game) StartDiscard() []string {
+	street := g.Type.Streets[g.Street]
+	g.State = Discarding
+	g.startTurn()
+	g.AwaitingDiscard = make(map[*Player]bool)
+	for _, player := range g.playersInPot() {
+		g.AwaitingDiscard[player] = true
+	}
 
-	return append(messages, g.CurOptions()...)
+	return []string{fmt.Sprintf("Everyone still in the hand must discard %s before %s. "+
+		"Message !discard followed by the cards, either here or in a DM to me.",
+		cardCount(street.Discard), street.Name)}
+}
+
+// Discard throws away cards from a player's hand, then deals the current
+// street once everyone has discarded
+func (g *Game) Discard(user *discordgo.User, cards []Card) []string {
+	player := g.GetPlayer(user)
+	if !g.IsAwaitingDiscard(user) {
+		return []string{"You don't need to discard right now!"}
+	}
+
+	count := g.Type.Streets[g.Street].Discard
+	if len(cards) != count {
+		return []string{fmt.Sprintf("You need to discard exactly %s!", cardCount(count))}
+	}
+
+	kept := make([]Card, len(player.Cards))
+	copy(kept, player.Cards)
+	for _, card := range cards {
+		i := slices.Index(kept, card)
+		if i == -1 {
+			return []string{fmt.Sprintf("You don't have %s in your hand!", card)}
+		}
+		kept = slices.Delete(kept, i, i+1)
+	}
+
+	player.Cards = kept
+	g.Deck.Discard(cards...)
+	delete(g.AwaitingDiscard, player)
+
+	messages := []string{fmt.Sprintf("%s has discarded.", player.Name)}
+	if len(g.AwaitingDiscard) > 0 {
+		return messages
+	}
+
+	g.State = HandsDealt
+	return append(messages, g.PlayStreet()...)
+}
+
+// IsAwaitingDiscard returns whether the user still has to discard
+func (g *Game) IsAwaitingDiscard(user *discordgo.User) bool {
+	player := g.GetPlayer(user)
+	return g.State == Discarding && player != nil && g.AwaitingDiscard[player]
+}
+
+// Returns a count of cards, e.g. "1 card" or "3 cards"
+func cardCount(n int) string {
+	if n == 1 {
+		return "1 card"
+	}
+	return fmt.Sprintf("%d cards", n)
+}
+
+// Starts a draw, where each player in the pot takes a turn discarding cards
+// and drawing replacements, starting from the left of the dealer
+func (g *Game) StartDraw() []string {
+	g.State = Drawing
+	g.DrawOrder = g.playersInPot()
+	g.recordStreet()
+	return g.NextDraw()
+}
+
+// Draw replaces the cards at the given positions, counting from one, in the
+// hand of the player whose turn it is to draw
+func (g *Game) Draw(positions []int) []string {
+	player := g.DrawOrder[0]
+
+	discarding := make(map[int]bool)
+	for _, pos := range positions {
+		if pos < 1 || pos > len(player.Cards) {
+			return []string{fmt.Sprintf("Card positions must be between 1 and %d!", len(player.Cards))}
+		}
+		if discarding[pos] {
+			return []string{"You can't discard the same card twice!"}
+		}
+		discarding[pos] = true
+	}
+
+	messages := []string{}
+	if len(positions) == 0 {
+		g.History.Draw(player, 0)
+		messages = append(messages, fmt.Sprintf("%s stands pat.", player.Name))
+	} else {
+		replacements := g.Deck.Deal(len(positions))
+		if replacements == nil {
+			return []string{"There aren't enough cards left to draw that many!"}
+		}
+		for i, pos := range positions {
+			g.Deck.Discard(player.Cards[pos-1])
+			player.Cards[pos-1] = replacements[i]
+		}
+		g.History.Draw(player, len(positions))
+		messages = append(messages, fmt.Sprintf("%s draws %s.", player.Name, cardCount(len(positions))))
+	}
+
+	g.DrawOrder = g.DrawOrder[1:]
+	return append(messages, g.NextDraw()...)
+}
+
+// Prompts the next player to draw, or goes back to betting once everyone has
+func (g *Game) NextDraw() []string {
+	if len(g.DrawOrder) > 0 {
+		g.startTurn()
+		messages := []string{fmt.Sprintf("It is %s's turn to draw.", g.DrawOrder[0].User.Mention())}
+		if g.Verbose {
+			messages = append(messages, "Message !draw followed by the positions of the cards to discard, or just !draw to stand pat.")
+		}
+		return messages
+	}
+
+	g.State = HandsDealt
+	if g.PotManager.BettingOver() {
+		return g.Showdown()
+	}
+
+	return g.startBetting()
+}
+
+// IsCurrentDrawer returns whether it's the user's turn to draw
+func (g *Game) IsCurrentDrawer(user *discordgo.User) bool {
+	return g.State == Drawing && len(g.DrawOrder) > 0 && g.DrawOrder[0].User.ID == user.ID
+}
+
+// Returns the index of the player who acts first after the first street
+func (g *Game) firstToAct() int {
+	if g.Type.Showing == nil {
+		return g.FirstBettor
+	}
+
+	// The best face-up cards act first, with ties going to whoever is
+	// closest to the left of the dealer
+	first, bestShowing := 0, 0
+	found := false
+	for _, player := range g.SeatOrder() {
+		for i, p := range g.InHand {
+			if p != player {
+				continue
+			}
+			showing := g.Type.Showing(player.UpCards)
+			if !found || showing > bestShowing {
+				first, bestShowing = i, showing
+				found = true
+			}
+		}
+	}
+	return first
 }
 
 func (g *Game) NextTurn() []string {
@@ -454,6 +1048,7 @@ func (g *Game) NextTurn() []string {
 	}
 
 	g.TurnIndex = (g.TurnIndex + 1) % len(g.InHand)
+	g.startTurn()
 	return g.CurOptions()
 }
 
@@ -482,6 +1077,8 @@ func (g *Game) CurOptions() []string {
 	if g.Verbose {
 		if g.GetCurrentPlayer().CurBet == curBet {
 			messages = append(messages, "Message !check, !raise or !fold.")
+		} else if !g.PotManager.CanRaise(g.GetCurrentPlayer()) {
+			messages = append(messages, "Message !call or !fold.")
 		} else if g.GetCurrentPlayer().MaxBet() > curBet {
 			messages = append(messages, "Message !call, !raise or !fold.")
 		} else {
@@ -493,6 +1090,14 @@ func (g *Game) CurOptions() []string {
 }
 
 func (g *Game) DealHands() []string {
+	pos, ok := g.nextPositions()
+	if !ok {
+		return []string{"Not enough players are ready to be dealt in!"}
+	}
+	if maxPlayers := g.Type.MaxPlayers(); g.countDealtIn(g.playerInSeat(pos.BigBlind)) > maxPlayers {
+		return []string{fmt.Sprintf("Too many players to deal %s! At most %d can play.", g.Type.String(), maxPlayers)}
+	}
+
 	g.Deck.Shuffle()
 
 	// Start out the shared cards as being empty
@@ -503,21 +1108,58 @@ func (g *Game) DealHands() []string {
 	g.InHand = make([]*Player, 0)
 
 	for _, player := range g.Players {
-		player.Cards = g.Type.DealHand()
+		player.Cards = nil
+		player.UpCards = nil
 		player.CurBet = 0
 		player.PlacedBet = false
-		g.InHand = append(g.InHand, player)
+		player.Mucking = false
 	}
+	dealt, removed := g.seatHand(pos)
+	g.InHand = append(g.InHand, dealt...)
+
+	// Reset the pot for the new hand
+	g.PotManager.NewHand(g.InHand)
 
 	g.State = HandsDealt
-	messages := []string{"The hands have been dealt!"}
+	g.Street = 0
+	g.Bets = 0
+	g.RunTwice = nil
+	g.Aggressor = nil
+	g.Unshown = nil
+	g.Rabbit = nil
+	messages := append(removed, "The hands have been dealt!")
+	messages = append(messages, g.dealStreet(g.Type.Streets[0])...)
 
-	// Reset the pot for the new hand
-	g.PotManager.NewHand(g.Players)
+	if g.Options.SmallBlind > 0 {
+		messages = append(messages, g.RaiseBlinds()...)
+	}
+	g.startHistory(pos)
 
-	// Pay blinds if there are any
+	// Pay the antes, then the blinds if there are any
 	if g.Options.SmallBlind > 0 {
-		messages = append(messages, g.PayBlinds()...)
+		messages = append(messages, g.PayAntes()...)
+		if g.Type.BringIn == nil {
+			messages = append(messages, g.PayBlinds()...)
+			// The big blind counts as the first bet
+			g.Bets = 1
+			messages = append(messages, g.PayStraddle()...)
+		}
+	}
+	g.Straddler = nil
+
+	// Hand histories show the first street after the blinds, but before the
+	// bring-in
+	g.recordStreet()
+	if g.Options.SmallBlind > 0 && g.Type.BringIn != nil {
+		messages = append(messages, g.PayBringIn()...)
+	}
+
+	// Anyone who went all in on their ante can't bet any more
+	for _, player := range slices.Clone(g.InHand) {
+		if player.Balance == 0 {
+			messages = append(messages, fmt.Sprintf("%s is all in!", player.Name))
+			g.LeaveHand(player)
+		}
 	}
 
 	g.TurnIndex--
@@ -530,6 +1172,7 @@ func (g *Game) EndGame() []string {
 	messages := []string{"Game has been ended."}
 	for _, player := range g.Players {
 		messages = append(messages, fmt.Sprintf("%s has $%d.", player.Name, player.Balance))
+		g.recordLedger(player, player.Balance, "Cashed out")
 	}
 
 	g.State = NoGame
@@ -543,12 +1186,36 @@ func (g *Game) ChangeGameType(gameType string) string {
 		newType = NewTexasHoldem()
 	case "plo":
 		newType = NewPotLimitOmaha()
+	case "plo5":
+		newType = NewPotLimitOmaha5()
+	case "plo6":
+		newType = NewPotLimitOmaha6()
+	case "plo8":
+		newType = NewPotLimitOmahaHiLo()
+	case "bigo":
+		newType = NewBigO()
+	case "shortdeck":
+		newType = NewShortDeckHoldem()
+	case "stud":
+		newType = NewSevenCardStud()
+	case "draw":
+		newType = NewFiveCardDraw()
+	case "27td":
+		newType = NewDeuceSevenTripleDraw()
+	case "razz":
+		newType = NewRazz()
+	case "pineapple":
+		newType = NewPineapple()
+	case "crazypineapple":
+		newType = NewCrazyPineapple()
 	default:
-		return "Invalid game type! Use 'holdem' or 'plo'"
+		return "Invalid game type! Use 'holdem', 'plo', 'plo5', 'plo6', 'plo8', 'bigo', 'shortdeck', 'pineapple', " +
+			"'crazypineapple', 'stud', 'razz', 'draw' or '27td'"
 	}
 
 	g.Type = &newType
 	g.Deck = newType.Deck
+	g.Options.Limit = newType.Limit
 	return fmt.Sprintf("Game type changed to %s", newType.String())
 }
 
@@ -559,13 +1226,58 @@ func (g *Game) ListOptions() string {
 		"Big Blind: $%d\n"+
 		"Min Buy-In: $%d\n"+
 		"Max Buy-In: $%d\n"+
-		"Blind Raise Delay: %d minutes (0 = off)",
-		g.Options.SmallBlind, g.Options.BigBlind, g.Options.MinBuyIn, g.Options.MaxBuyIn, g.Options.RaiseDelay)
+		"Blind Raise Delay: %d minutes (0 = off)\n"+
+		"Betting: %s\n"+
+		"Ante: $%d\n"+
+		"Big Blind Ante: $%d\n"+
+		"Straddles: %s\n"+
+		"Mississippi Straddles: %s\n"+
+		"Action Timer: %d seconds (0 = off)\n"+
+		"Time Bank: %d seconds\n"+
+		"Sit Out Limit: %d orbits (0 = off)",
+		g.Options.SmallBlind, g.Options.BigBlind, g.Options.MinBuyIn, g.Options.MaxBuyIn, g.Options.RaiseDelay,
+		g.Options.Limit, g.Options.Ante, g.Options.BigBlindAnte, onOff(g.Options.Straddle), onOff(g.Options.Mississippi),
+		g.Options.Timer, g.Options.TimeBank, g.Options.SitOutOrbits)
+}
+
+// Returns "on" or "off" for a toggled option
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
 }
 
 // HandleOptions handles the options command and returns messages to be sent
 func (g *Game) SetOption(args []string) string {
 	option := strings.ToLower(args[0])
+	if option == "straddle" || option == "mississippi" {
+		var on bool
+		switch strings.ToLower(args[1]) {
+		case "on":
+			on = true
+		case "off":
+			on = false
+		default:
+			return "Invalid setting! Use on or off"
+		}
+		if option == "straddle" {
+			g.Options.Straddle = on
+		} else {
+			g.Options.Mississippi = on
+		}
+		return fmt.Sprintf("%s set to %s", option, onOff(on))
+	}
+
+	if option == "limit" {
+		limit, err := ParseBettingStructure(args[1])
+		if err != nil {
+			return "Invalid betting structure! Use nl, pl or fl"
+		}
+		g.Options.Limit = limit
+		return fmt.Sprintf("Betting structure set to %s", limit)
+	}
+
 	amount, err := strconv.Atoi(args[1])
 	if err != nil {
 		return "Invalid amount!"
@@ -606,8 +1318,42 @@ func (g *Game) SetOption(args []string) string {
 			return "Delay must be 0 or greater!"
 		}
 		g.Options.RaiseDelay = amount
+	case "ante":
+		if amount < 0 {
+			return "Ante must be 0 or greater!"
+		}
+		if amount > 0 && g.Options.BigBlindAnte > 0 {
+			return "Turn off the big blind ante before setting an ante!"
+		}
+		g.Options.Ante = amount
+	case "bbante":
+		if amount < 0 {
+			return "Big blind ante must be 0 or greater!"
+		}
+		if amount > 0 && g.Options.Ante > 0 {
+			return "Turn off the ante before setting a big blind ante!"
+		}
+		g.Options.BigBlindAnte = amount
+	case "timer":
+		if amount < 0 {
+			return "Timer must be 0 or greater!"
+		}
+		g.Options.Timer = amount
+	case "timebank":
+		if amount < 0 {
+			return "Time bank must be 0 or greater!"
+		}
+		g.Options.TimeBank = amount
+		for _, player := range g.Players {
+			player.TimeBank = amount
+		}
+	case "sitout":
+		if amount < 0 {
+			return "Sit out limit must be 0 or greater!"
+		}
+		g.Options.SitOutOrbits = amount
 	default:
-		return "Invalid option! Use sb, bb, min, max, or delay"
+		return "Invalid option! Use sb, bb, min, max, delay, ante, bbante, timer, timebank, sitout, limit, straddle or mississippi"
 	}
 
 	return fmt.Sprintf("%s set to %d", option, amount)
@@ -623,6 +1369,19 @@ func (g *Game) IsCurrentPlayer(user *discordgo.User) bool {
 	return currentPlayer != nil && currentPlayer.User.ID == user.ID
 }
 
+// HandInProgress returns whether a hand is currently being played
+func (g *Game) HandInProgress() bool {
+	return g.State == HandsDealt || g.State == Drawing || g.State == Discarding || g.State == ChoosingRunouts
+}
+
+// TakeNewHoleCards returns whether face-down cards have been dealt since the
+// last time it was called
+func (g *Game) TakeNewHoleCards() bool {
+	dealt := g.NewHoleCards && g.HandInProgress()
+	g.NewHoleCards = false
+	return dealt
+}
+
 func (g *Game) BetweenHands() bool {
 	return g.State == NoGame || g.State == Waiting || g.State == NoHands
 }
@@ -22,9 +22,26 @@ const (
 type Hand struct {
 	Cards []Card
 	Rank  HandRanking
+	// The canonical strength of the hand, which orders every hand correctly
+	// and is equal for hands that tie
+	Score HandScore
+	// Whether this is a low hand, where the lowest cards win
+	Low bool
 }
 
+// NewHand builds a hand out of five cards using standard hand rankings
 func NewHand(cards []Card) Hand {
+	return standardEvaluator.newHand(cards)
+}
+
+// NewShortDeckHand builds a hand out of five cards using short deck hand
+// rankings
+func NewShortDeckHand(cards []Card) Hand {
+	return shortDeckEvaluator.newHand(cards)
+}
+
+// Builds a hand out of five cards, ranked by the evaluator
+func (e *evaluator) newHand(cards []Card) Hand {
 	// Sort the cards to make comparison easier
 	sort.Slice(cards, func(i, j int) bool {
 		return cards[i].Less(cards[j])
@@ -32,45 +49,31 @@ func NewHand(cards []Card) Hand {
 
 	h := Hand{
 		Cards: cards,
+		Score: e.eval5(
+			encodeCard(cards[0]), encodeCard(cards[1]), encodeCard(cards[2]),
+			encodeCard(cards[3]), encodeCard(cards[4]),
+		),
 	}
+	h.Rank = e.ranking(h.Score)
 
-	// Get duplicates (pairs, three-of-a-kinds, etc)
-	dups := h.getDuplicates()
-
-	// Determine hand ranking
-	if h.isFlush() {
-		if h.isStraight() {
-			h.Rank = StraightFlush
-		} else {
-			h.Rank = Flush
-		}
-	} else if h.isStraight() {
-		h.Rank = Straight
-	} else if len(dups) > 0 {
-		if len(dups) == 2 {
-			if len(dups[1]) == 3 {
-				h.Rank = FullHouse
-			} else {
-				h.Rank = TwoPair
-			}
-		} else {
-			if len(dups[0]) == 4 {
-				h.Rank = FourOfKind
-			} else if len(dups[0]) == 3 {
-				h.Rank = ThreeOfKind
-			} else {
-				h.Rank = Pair
-			}
+	switch h.Rank {
+	case Straight, StraightFlush:
+		// In an ace-low straight the ace plays as the lowest card
+		if h.Cards[4].Rank == "A" && h.Cards[3].Rank != "K" {
+			h.Cards = append([]Card{h.Cards[4]}, h.Cards[:4]...)
 		}
-		h.rearrangeDuplicates(dups)
-	} else {
-		h.Rank = HighCard
+	case Pair, TwoPair, ThreeOfKind, FullHouse, FourOfKind:
+		h.rearrangeDuplicates(h.getDuplicates())
 	}
 
 	return h
 }
 
 func (h Hand) String() string {
+	if h.Low && h.Rank == HighCard {
+		return h.Cards[4].Rank + "-" + h.Cards[3].Rank + " low"
+	}
+
 	switch h.Rank {
 	case HighCard:
 		return h.Cards[4].Name() + " high"
@@ -99,63 +102,11 @@ func (h Hand) String() string {
 }
 
 func (h Hand) Less(other Hand) bool {
-	if h.Rank < other.Rank {
-		return true
-	}
-	if h.Rank > other.Rank {
-		return false
-	}
-	// Compare cards from highest to lowest
-	for i := len(h.Cards) - 1; i >= 0; i-- {
-		if h.Cards[i].Less(other.Cards[i]) {
-			return true
-		}
-		if other.Cards[i].Less(h.Cards[i]) {
-			return false
-		}
-	}
-	return false
+	return h.Score < other.Score
 }
 
 func (h Hand) Equal(other Hand) bool {
-	if h.Rank != other.Rank {
-		return false
-	}
-	for i := range h.Cards {
-		if !h.Cards[i].Equal(other.Cards[i]) {
-			return false
-		}
-	}
-	return true
-}
-
-func (h Hand) isStraight() bool {
-	// Check normal straight
-	for i := 1; i < 5; i++ {
-		if h.Cards[i-1].Value() != h.Cards[i].Value()-1 {
-			break
-		}
-		if i == 4 {
-			return true
-		}
-	}
-
-	// Check ace-low straight
-	values := make([]int, 5)
-	for i, card := range h.Cards {
-		values[i] = card.Value()
-	}
-	return values[0] == 0 && values[1] == 1 && values[2] == 2 && values[3] == 3 && values[4] == 12
-}
-
-func (h Hand) isFlush() bool {
-	suit := h.Cards[0].Suit
-	for _, card := range h.Cards[1:] {
-		if card.Suit != suit {
-			return false
-		}
-	}
-	return true
+	return h.Score == other.Score
 }
 
 // Returns a list of the pairs, three-of-a-kinds and four-of-a-kinds in the hand
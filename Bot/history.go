@@ -0,0 +1,459 @@
+package Bot
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go-poker-bot/Bot/util"
+)
+
+// The names PokerStars gives each game in its hand histories
+var historyGameNames = map[GameType]string{
+	TexasHoldemType:          "Hold'em",
+	PotLimitOmahaType:        "Omaha",
+	PotLimitOmahaHiLoType:    "Omaha Hi/Lo",
+	ShortDeckHoldemType:      "6+ Hold'em",
+	SevenCardStudType:        "7 Card Stud",
+	FiveCardDrawType:         "5 Card Draw",
+	DeuceSevenTripleDrawType: "Triple Draw 2-7 Lowball",
+	RazzType:                 "Razz",
+	PineappleType:            "Pineapple",
+	CrazyPineappleType:       "Crazy Pineapple",
+	PotLimitOmaha5Type:       "5 Card Omaha",
+	PotLimitOmaha6Type:       "6 Card Omaha",
+	BigOType:                 "5 Card Omaha Hi/Lo",
+}
+
+// The letters PokerStars uses for each suit
+var historySuits = map[string]string{
+	Spade:   "s",
+	Heart:   "h",
+	Diamond: "d",
+	Club:    "c",
+}
+
+// The separator between hands in a hand history file
+const historySeparator = "\n\n\n"
+
+// The number of the last hand recorded. Hands are numbered by the time they
+// start, going up by one when two start at once
+var lastHandNumber atomic.Int64
+
+// Returns a unique number for a new hand
+func nextHandNumber() int64 {
+	for {
+		last := lastHandNumber.Load()
+		next := int64(util.Max(int(last)+1, int(time.Now().UnixMilli())))
+		if lastHandNumber.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
+
+// HistorySeat is a player dealt into a recorded hand
+type HistorySeat struct {
+	UserID string
+	Name   string
+	Seat   int
+	// The player's positions, e.g. "button" or "big blind"
+	Positions []string
+}
+
+// HandHistory records a hand as it's played, in the text format PokerStars
+// uses for its hand histories. Players are kept by user ID, so that it can be
+// saved along with the game. Recording to a nil history does nothing
+type HandHistory struct {
+	Lines []string
+	Seats []HistorySeat
+	// Where the hand is up to, in the form used to say when players folded,
+	// e.g. "before Flop"
+	Street string
+	// When each player who folded did so
+	Folded map[string]string
+	// The cards each player showed at showdown
+	Shown map[string]string
+	// The players who mucked at showdown
+	Mucked map[string]bool
+	// The amount each player won
+	Won map[string]int
+	// The amount each player has bet on the current street
+	Bets map[string]int
+}
+
+// Returns the card as PokerStars writes it, e.g. "Th"
+func historyCard(card Card) string {
+	rank := card.Rank
+	if rank == "10" {
+		rank = "T"
+	}
+	return rank + historySuits[card.Suit]
+}
+
+// Returns the cards as PokerStars writes them, e.g. "[Ah Kd]"
+func historyCards(cards []Card) string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = historyCard(card)
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
+
+// Returns the game and stakes for the hand history header, where fixed-limit
+// games give the small and big bets rather than the blinds
+func (g *Game) historyStakes() string {
+	limit := g.Options.Limit.String()
+	small, big := g.Options.SmallBlind, g.Options.BigBlind
+	if g.Options.Limit == FixedLimit {
+		limit = "Limit"
+		small, big = g.Options.BigBlind, 2*g.Options.BigBlind
+	}
+	return fmt.Sprintf("%s %s ($%d/$%d USD)", historyGameNames[g.Type.GameType], limit, small, big)
+}
+
+// Starts recording the hand being dealt, with every player's seat and stack
+// before they pay anything
+func (g *Game) startHistory(pos Positions) {
+	now, zone := time.Now().UTC(), "UTC"
+	if eastern, err := time.LoadLocation("America/New_York"); err == nil {
+		now, zone = now.In(eastern), "ET"
+	}
+
+	h := &HandHistory{
+		Folded: make(map[string]string),
+		Shown:  make(map[string]string),
+		Mucked: make(map[string]bool),
+		Won:    make(map[string]int),
+		Bets:   make(map[string]int),
+	}
+	h.add("PokerStars Hand #%d: %s - %s %s", nextHandNumber(), g.historyStakes(), now.Format("2006/01/02 15:04:05"), zone)
+	table := fmt.Sprintf("Table '%s' %d-max", g.ChannelID, util.Max(9, g.nextSeat()))
+	if g.Type.BringIn == nil {
+		table += fmt.Sprintf(" Seat #%d is the button", pos.Button+1)
+	}
+	h.add("%s", table)
+
+	for _, player := range g.Players {
+		if !wasDealtIn(player) {
+			h.add("Seat %d: %s ($%d in chips) is sitting out", player.Seat+1, player.Name, player.Balance)
+			continue
+		}
+		h.add("Seat %d: %s ($%d in chips)", player.Seat+1, player.Name, player.Balance)
+
+		seat := HistorySeat{UserID: player.User.ID, Name: player.Name, Seat: player.Seat}
+		if g.Type.BringIn == nil {
+			if player.Seat == pos.Button {
+				seat.Positions = append(seat.Positions, "button")
+			}
+			if player.Seat == pos.SmallBlind {
+				seat.Positions = append(seat.Positions, "small blind")
+			}
+			if player.Seat == pos.BigBlind {
+				seat.Positions = append(seat.Positions, "big blind")
+			}
+		}
+		h.Seats = append(h.Seats, seat)
+	}
+	g.History = h
+}
+
+// Adds a line to the history
+func (h *HandHistory) add(format string, args ...any) {
+	if h == nil {
+		return
+	}
+	h.Lines = append(h.Lines, fmt.Sprintf(format, args...))
+}
+
+// Adds an action where the player puts money in, noting if it put them all in
+func (h *HandHistory) action(player *Player, format string, args ...any) {
+	if h == nil {
+		return
+	}
+	allIn := ""
+	if player.Balance == 0 {
+		allIn = " and is all-in"
+	}
+	h.add("%s: %s%s", player.Name, fmt.Sprintf(format, args...), allIn)
+	h.Bets[player.User.ID] = player.CurBet
+}
+
+// Post records a forced bet, e.g. "small blind" or "the ante"
+func (h *HandHistory) Post(player *Player, blind string, amount int) {
+	h.action(player, "posts %s $%d", blind, amount)
+}
+
+// BringIn records the player bringing in the betting in a stud game
+func (h *HandHistory) BringIn(player *Player, amount int) {
+	h.action(player, "brings in for $%d", amount)
+}
+
+// Fold records the player folding
+func (h *HandHistory) Fold(player *Player) {
+	if h == nil {
+		return
+	}
+	h.add("%s: folds", player.Name)
+	h.Folded[player.User.ID] = h.Street
+}
+
+// Check records the player checking
+func (h *HandHistory) Check(player *Player) {
+	h.add("%s: checks", player.Name)
+}
+
+// Call records the player calling, given how much more they put in
+func (h *HandHistory) Call(player *Player, amount int) {
+	if amount == 0 {
+		h.Check(player)
+		return
+	}
+	h.action(player, "calls $%d", amount)
+}
+
+// Raise records the player raising the bet they had to meet, or betting if
+// there wasn't one
+func (h *HandHistory) Raise(player *Player, from int) {
+	if from == 0 {
+		h.action(player, "bets $%d", player.CurBet)
+		return
+	}
+	h.action(player, "raises $%d to $%d", player.CurBet-from, player.CurBet)
+}
+
+// Complete records the player completing the bring-in to a full bet
+func (h *HandHistory) Complete(player *Player) {
+	h.action(player, "completes it to $%d", player.CurBet)
+}
+
+// Draw records the player drawing replacements for some of their cards
+func (h *HandHistory) Draw(player *Player, count int) {
+	if count == 0 {
+		h.add("%s: stands pat", player.Name)
+		return
+	}
+	h.add("%s: discards %s", player.Name, cardCount(count))
+}
+
+// Records the street that's just been dealt, with the cards dealt face up
+func (g *Game) recordStreet() {
+	h := g.History
+	if h == nil {
+		return
+	}
+	street := g.Type.Streets[g.Street]
+	if g.Street > 0 {
+		h.Bets = make(map[string]int)
+	}
+
+	switch {
+	case g.Type.BoardSize() > 0:
+		names := []string{"HOLE CARDS", "FLOP", "TURN", "RIVER"}
+		name := names[util.Min(g.Street, len(names)-1)]
+		if g.Street == 0 {
+			h.add("*** HOLE CARDS ***")
+			h.Street = "before Flop"
+			return
+		}
+		dealt := len(g.Community) - street.Community
+		if dealt > 0 {
+			h.add("*** %s *** %s %s", name, historyCards(g.Community[:dealt]), historyCards(g.Community[dealt:]))
+		} else {
+			h.add("*** %s *** %s", name, historyCards(g.Community))
+		}
+		h.Street = "on the " + strings.ToUpper(name[:1]) + strings.ToLower(name[1:])
+	case g.Type.BringIn != nil:
+		if g.Street == len(g.Type.Streets)-1 {
+			h.add("*** RIVER ***")
+			h.Street = "on the River"
+			return
+		}
+		name := fmt.Sprintf("%s STREET", ordinal(g.Street+3))
+		h.add("*** %s ***", name)
+		h.Street = fmt.Sprintf("on the %s Street", ordinal(g.Street+3))
+		for _, player := range g.playersInPot() {
+			up := player.UpCards
+			dealt := len(up) - street.Up
+			if dealt > 0 {
+				h.add("Dealt to %s %s %s", player.Name, historyCards(up[:dealt]), historyCards(up[dealt:]))
+			} else {
+				h.add("Dealt to %s %s", player.Name, historyCards(up))
+			}
+		}
+	default:
+		if g.Street == 0 {
+			h.add("*** DEALING HANDS ***")
+			h.Street = "before the Draw"
+			return
+		}
+		draws := []string{"FIRST", "SECOND", "THIRD"}
+		h.add("*** %s DRAW ***", draws[util.Min(g.Street, len(draws))-1])
+		h.Street = fmt.Sprintf("after the %s Draw", ordinal(g.Street))
+	}
+}
+
+// Returns a number as an ordinal, e.g. "3rd"
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+// Show records the player showing their hand at showdown
+func (h *HandHistory) Show(player *Player) {
+	if h == nil {
+		return
+	}
+	cards := historyCards(player.AllCards())
+	h.add("%s: shows %s", player.Name, cards)
+	h.Shown[player.User.ID] = cards
+}
+
+// Muck records the player mucking their hand at showdown
+func (h *HandHistory) Muck(player *Player) {
+	if h == nil {
+		return
+	}
+	h.add("%s: mucks hand", player.Name)
+	h.Mucked[player.User.ID] = true
+}
+
+// Collect records the player winning money from the pot
+func (h *HandHistory) Collect(player *Player, amount int) {
+	if h == nil {
+		return
+	}
+	h.add("%s collected $%d from pot", player.Name, amount)
+	h.Won[player.User.ID] += amount
+}
+
+// Records the player winning the pot when everyone else folds, giving back
+// the part of their bet that nobody called
+func (g *Game) recordFoldWin(winner *Player) {
+	h := g.History
+	if h == nil {
+		return
+	}
+
+	called := 0
+	for id, bet := range h.Bets {
+		if id != winner.User.ID {
+			called = util.Max(called, bet)
+		}
+	}
+	total := g.PotManager.Value()
+	if uncalled := h.Bets[winner.User.ID] - called; uncalled > 0 {
+		h.add("Uncalled bet ($%d) returned to %s", uncalled, winner.Name)
+		total -= uncalled
+	}
+	h.Collect(winner, total)
+	g.finishHistory(total, [][]Card{g.Community})
+}
+
+// Finishes recording the hand with its summary, given the pot that was played
+// for and the boards it was played on, and sets it aside to be saved
+func (g *Game) finishHistory(total int, boards [][]Card) {
+	h := g.History
+	if h == nil {
+		return
+	}
+	g.History = nil
+
+	h.add("*** SUMMARY ***")
+	h.add("Total pot $%d | Rake $0", total)
+	if len(boards) == 2 {
+		h.add("Hand was run twice")
+		h.add("FIRST Board %s", historyCards(boards[0]))
+		h.add("SECOND Board %s", historyCards(boards[1]))
+	} else if len(boards) == 1 && len(boards[0]) > 0 {
+		h.add("Board %s", historyCards(boards[0]))
+	}
+
+	for _, seat := range h.Seats {
+		name := seat.Name
+		for _, position := range seat.Positions {
+			name += " (" + position + ")"
+		}
+
+		won := h.Won[seat.UserID]
+		var outcome string
+		if cards, ok := h.Shown[seat.UserID]; ok {
+			if won > 0 {
+				outcome = fmt.Sprintf("showed %s and won ($%d)", cards, won)
+			} else {
+				outcome = fmt.Sprintf("showed %s and lost", cards)
+			}
+		} else if h.Mucked[seat.UserID] {
+			outcome = "mucked"
+		} else if won > 0 {
+			outcome = fmt.Sprintf("collected ($%d)", won)
+		} else if street, ok := h.Folded[seat.UserID]; ok {
+			outcome = "folded " + street
+		} else {
+			outcome = "mucked"
+		}
+		h.add("Seat %d: %s %s", seat.Seat+1, name, outcome)
+	}
+
+	g.finishedHands = append(g.finishedHands, strings.Join(h.Lines, "\n"))
+}
+
+// TakeHandHistories returns the histories of the hands finished since it was
+// last called, to be saved
+func (g *Game) TakeHandHistories() []string {
+	hands := g.finishedHands
+	g.finishedHands = nil
+	return hands
+}
+
+// AppendHandHistories adds the hands to the end of the history file at the
+// path
+func AppendHandHistories(path string, hands []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	for _, hand := range hands {
+		if _, err := f.WriteString(hand + historySeparator); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// RecentHandHistories returns the last n hands from the history file at the
+// path, oldest first
+func RecentHandHistories(path string, n int) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hands []string
+	for _, hand := range strings.Split(string(data), historySeparator) {
+		if hand = strings.TrimSpace(hand); hand != "" {
+			hands = append(hands, hand)
+		}
+	}
+	return hands[util.Max(0, len(hands)-n):], nil
+}
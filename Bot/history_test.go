@@ -0,0 +1,115 @@
+package Bot
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHandHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType string
+		showdown bool
+		want     []string
+	}{
+		{
+			name:     "Fold",
+			gameType: "holdem",
+			showdown: false,
+			want: []string{
+				"Table 'table' 9-max Seat #1 is the button",
+				"Seat 1: A ($100 in chips)",
+				"B: posts small blind $1",
+				"C: posts big blind $2",
+				"*** HOLE CARDS ***",
+				"A: raises $4 to $6",
+				"B: folds",
+				"C: folds",
+				"Uncalled bet ($4) returned to A",
+				"A collected $5 from pot",
+				"Total pot $5 | Rake $0",
+				"Seat 1: A (button) collected ($5)",
+				"Seat 2: B (small blind) folded before Flop",
+			},
+		},
+		{
+			name:     "Showdown",
+			gameType: "holdem",
+			showdown: true,
+			want: []string{
+				"A: raises $4 to $6",
+				"B: calls $5",
+				"C: calls $4",
+				"*** RIVER ***",
+				"*** SHOW DOWN ***",
+				"Total pot $18 | Rake $0",
+			},
+		},
+		{
+			name:     "Stud",
+			gameType: "stud",
+			showdown: true,
+			want: []string{
+				"Table 'table' 9-max",
+				"*** 3rd STREET ***",
+				"*** RIVER ***",
+				"*** SHOW DOWN ***",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.gameType, 3)
+			game.ChannelID = "table"
+			game.DealHands()
+			if tt.gameType == "holdem" {
+				game.Raise(4)
+			}
+			if tt.showdown {
+				for game.HandInProgress() {
+					game.Call()
+				}
+			} else {
+				foldHand(game)
+			}
+
+			hands := game.TakeHandHistories()
+			if len(hands) != 1 {
+				t.Fatalf("expected 1 hand history, got %d", len(hands))
+			}
+			lines := strings.Split(hands[0], "\n")
+			if !strings.HasPrefix(lines[0], "PokerStars Hand #") {
+				t.Errorf("expected a PokerStars header, got %q", lines[0])
+			}
+			for _, want := range tt.want {
+				if !slices.ContainsFunc(lines, func(line string) bool { return strings.HasPrefix(line, want) }) {
+					t.Errorf("expected a line starting %q in:\n%s", want, hands[0])
+				}
+			}
+			if game.TakeHandHistories() != nil {
+				t.Errorf("expected hand histories to only be taken once")
+			}
+		})
+	}
+}
+
+func TestRecentHandHistories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "histories", "channel.txt")
+	if hands, err := RecentHandHistories(path, 5); err != nil || len(hands) != 0 {
+		t.Fatalf("expected no hands before any are saved, got %v, %v", hands, err)
+	}
+
+	AppendHandHistories(path, []string{"hand 1\nline", "hand 2"})
+	AppendHandHistories(path, []string{"hand 3"})
+
+	hands, err := RecentHandHistories(path, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(hands, []string{"hand 2", "hand 3"}) {
+		t.Errorf("expected the last 2 hands, got %q", hands)
+	}
+}
@@ -0,0 +1,147 @@
+package Bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go-poker-bot/Bot/util"
+)
+
+// The number of transactions shown by !bankroll
+const bankrollHistory = 10
+
+// Transaction is a single change to a user's bankroll
+type Transaction struct {
+	Time   time.Time
+	Amount int
+	// What the money was for, e.g. buying in or cashing out
+	Description string
+}
+
+// Account is a user's bankroll in a guild, and how it got there
+type Account struct {
+	Name    string
+	Balance int
+	History []Transaction
+}
+
+// Ledger keeps a running bankroll for each user in each guild, across games,
+// saved to a file after every change
+type Ledger struct {
+	mu   sync.Mutex
+	path string
+	// Accounts by guild ID, then by user ID
+	Accounts map[string]map[string]*Account
+}
+
+// LoadLedger reads the ledger saved at the path, or starts an empty one if
+// there isn't one yet
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{
+		path:     path,
+		Accounts: make(map[string]map[string]*Account),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.Accounts); err != nil {
+		return nil, fmt.Errorf("reading ledger %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Record adds a transaction to the user's account and saves the ledger
+func (l *Ledger) Record(guildID, userID, name string, amount int, description string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	guild, ok := l.Accounts[guildID]
+	if !ok {
+		guild = make(map[string]*Account)
+		l.Accounts[guildID] = guild
+	}
+	account, ok := guild[userID]
+	if !ok {
+		account = &Account{}
+		guild[userID] = account
+	}
+
+	account.Name = name
+	account.Balance += amount
+	account.History = append(account.History, Transaction{
+		Time:        time.Now(),
+		Amount:      amount,
+		Description: description,
+	})
+	return l.save()
+}
+
+// Returns a copy of the user's account, and whether they have one
+func (l *Ledger) Account(guildID, userID string) (Account, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	account, ok := l.Accounts[guildID][userID]
+	if !ok {
+		return Account{}, false
+	}
+	result := *account
+	result.History = append([]Transaction(nil), account.History...)
+	return result, true
+}
+
+// Bankroll describes the user's balance and most recent transactions
+func (l *Ledger) Bankroll(guildID, userID string) string {
+	account, ok := l.Account(guildID, userID)
+	if !ok {
+		return "You don't have a bankroll yet! Buy in to a game to start one."
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%s's bankroll: %s**", account.Name, formatAmount(account.Balance))
+	history := account.History[util.Max(0, len(account.History)-bankrollHistory):]
+	for i := len(history) - 1; i >= 0; i-- {
+		t := history[i]
+		fmt.Fprintf(&sb, "\n%s %s %s", t.Time.Format("2006-01-02"), formatAmount(t.Amount), t.Description)
+	}
+	return sb.String()
+}
+
+// Writes the ledger to its file
+func (l *Ledger) save() error {
+	data, err := json.MarshalIndent(l.Accounts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(l.path, data)
+}
+
+// Formats an amount of money with its sign
+func formatAmount(amount int) string {
+	if amount < 0 {
+		return fmt.Sprintf("-$%d", -amount)
+	}
+	return fmt.Sprintf("+$%d", amount)
+}
+
+// Records a change to the player's bankroll in the game's ledger, if it has one
+func (g *Game) recordLedger(player *Player, amount int, description string) {
+	if g.Ledger == nil {
+		return
+	}
+	if err := g.Ledger.Record(g.GuildID, player.User.ID, player.Name, amount, description); err != nil {
+		log.Printf("Error saving the ledger: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package Bot
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+	ledger, err := LoadLedger(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading a new ledger: %v", err)
+	}
+
+	game := newTestGame("holdem", 3)
+	game.Ledger = ledger
+	game.GuildID = "guild"
+	game.BuyIn(game.Players[0].User, 100, false)
+	game.DealHands()
+	foldHand(game)
+	game.Leave(game.Players[0].User)
+	game.EndGame()
+
+	// Reloading the ledger shows what each player bought in for and cashed out
+	ledger, err = LoadLedger(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading the ledger: %v", err)
+	}
+	tests := []struct {
+		user    string
+		balance int
+		history int
+	}{
+		{user: "A", balance: 100, history: 2},
+		{user: "B", balance: 99, history: 1},
+		{user: "C", balance: 101, history: 1},
+	}
+	for _, tt := range tests {
+		account, ok := ledger.Account("guild", tt.user)
+		if !ok {
+			t.Errorf("expected %s to have an account", tt.user)
+			continue
+		}
+		if account.Balance != tt.balance || len(account.History) != tt.history {
+			t.Errorf("expected %s to have $%d over %d transactions, got $%d over %d",
+				tt.user, tt.balance, tt.history, account.Balance, len(account.History))
+		}
+	}
+
+	if _, ok := ledger.Account("other", "A"); ok {
+		t.Errorf("expected bankrolls to be kept separately for each guild")
+	}
+}
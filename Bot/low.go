@@ -0,0 +1,114 @@
+package Bot
+
+import (
+	"sort"
+)
+
+// Deuce-to-seven rankings, where aces are always high so A-2-3-4-5 isn't a
+// straight
+var deuceSevenEvaluator = newEvaluator(
+	[]HandRanking{HighCard, Pair, TwoPair, ThreeOfKind, Straight, Flush, FullHouse, FourOfKind, StraightFlush},
+	[]uint32{
+		straightMask(0), straightMask(1), straightMask(2), straightMask(3), straightMask(4),
+		straightMask(5), straightMask(6), straightMask(7), straightMask(8),
+	},
+)
+
+// NewDeuceSevenHand builds a deuce-to-seven low hand out of five cards. Aces
+// are always high and straights and flushes count against the hand, so the
+// best possible low is 7-5-4-3-2
+func NewDeuceSevenHand(cards []Card) Hand {
+	h := deuceSevenEvaluator.newHand(cards)
+	h.Low = true
+	// The weakest high hand is the best low, so the score is flipped
+	h.Score = deuceSevenEvaluator.maxScore() + 1 - h.Score
+	return h
+}
+
+// Returns the best deuce-to-seven low hand that can be made out of a
+// player's cards
+func DeuceSevenBestHand(community []Card, hole []Card) (Hand, error) {
+	allCards := make([]Card, 0, len(community)+len(hole))
+	allCards = append(allCards, community...)
+	allCards = append(allCards, hole...)
+	if err := checkHandSize(len(allCards)); err != nil {
+		return Hand{}, err
+	}
+
+	_, combo := deuceSevenEvaluator.worst(encodeCards(allCards))
+	handCards := make([]Card, 5)
+	for i, idx := range combo {
+		handCards[i] = allCards[idx]
+	}
+	return NewDeuceSevenHand(handCards), nil
+}
+
+// NewLowHand builds an ace-to-five low hand out of five cards. Aces are
+// always low and straights and flushes don't count against the hand, so the
+// best possible low is 5-4-3-2-A
+func NewLowHand(cards []Card) Hand {
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].LowValue() < cards[j].LowValue()
+	})
+
+	h := Hand{
+		Cards: cards,
+		Low:   true,
+	}
+
+	dups := h.getDuplicates()
+	switch {
+	case len(dups) == 0:
+		h.Rank = HighCard
+	case len(dups) == 2 && len(dups[1]) == 3:
+		h.Rank = FullHouse
+	case len(dups) == 2:
+		h.Rank = TwoPair
+	case len(dups[0]) == 4:
+		h.Rank = FourOfKind
+	case len(dups[0]) == 3:
+		h.Rank = ThreeOfKind
+	default:
+		h.Rank = Pair
+	}
+	h.rearrangeDuplicates(dups)
+
+	// Unpaired hands beat paired ones, then the highest cards are compared,
+	// with the duplicated cards (at the end of the hand) counting first. A
+	// lower key is a better low, so it's flipped into a score
+	key := int(h.Rank)
+	for i := len(h.Cards) - 1; i >= 0; i-- {
+		key = key<<4 | h.Cards[i].LowValue()
+	}
+	h.Score = HandScore(1<<24 - key)
+
+	return h
+}
+
+// Returns whether the hand is an unpaired low with every card eight or lower
+func (h Hand) isEightOrBetter() bool {
+	return h.Low && h.Rank == HighCard && h.Cards[4].LowValue() <= 8
+}
+
+// Returns the best qualifying eight-or-better low that can be made from the
+// community cards and a player's hole cards, using exactly 2 hole cards and
+// exactly 3 community cards. Returns an empty hand if there is no low
+func OmahaLowHand(community []Card, hole []Card) (Hand, error) {
+	if err := checkOmahaCards(community, hole); err != nil {
+		return Hand{}, err
+	}
+
+	var best Hand
+	for _, h := range combos[len(hole)][2] {
+		for _, c := range combos[len(community)][3] {
+			hand := NewLowHand([]Card{
+				hole[h[0]], hole[h[1]],
+				community[c[0]], community[c[1]], community[c[2]],
+			})
+			if hand.isEightOrBetter() && best.Less(hand) {
+				best = hand
+			}
+		}
+	}
+	return best, nil
+}
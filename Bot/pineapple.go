@@ -0,0 +1,42 @@
+package Bot
+
+// NewPineapple creates a new Pineapple game, which is played like Texas
+// Hold'em except that players are dealt three hole cards and discard one of
+// them before the flop
+func NewPineapple() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	streets := boardStreets(3)
+	streets[1].Discard = 1
+
+	return PokerType{
+		GameType: PineappleType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: TexasHoldemBestHand,
+		Streets:  streets,
+		String: func() string {
+			return "Pineapple"
+		},
+	}
+}
+
+// NewCrazyPineapple creates a new Crazy Pineapple game, where players keep
+// all three hole cards until the betting on the flop is over
+func NewCrazyPineapple() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	streets := boardStreets(3)
+	streets[2].Discard = 1
+
+	return PokerType{
+		GameType: CrazyPineappleType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: TexasHoldemBestHand,
+		Streets:  streets,
+		String: func() string {
+			return "Crazy Pineapple"
+		},
+	}
+}
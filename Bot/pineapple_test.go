@@ -0,0 +1,68 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestPineappleDiscard(t *testing.T) {
+	tests := []struct {
+		name         string
+		gameType     string
+		discardRound int
+	}{
+		{name: "Pineapple", gameType: "pineapple", discardRound: 1},
+		{name: "Crazy Pineapple", gameType: "crazypineapple", discardRound: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.gameType, 3)
+			game.DealHands()
+
+			for _, player := range game.Players {
+				if len(player.Cards) != 3 {
+					t.Fatalf("%s was dealt %d cards", player.Name, len(player.Cards))
+				}
+			}
+
+			// Everyone calls the big blind, then checks until the discard
+			game.Call()
+			game.Call()
+			game.Check()
+			for game.Street < tt.discardRound && game.GetState() == HandsDealt {
+				game.Check()
+			}
+			if game.GetState() != Discarding {
+				t.Fatalf("expected a discard before street %d, on street %d", tt.discardRound, game.Street)
+			}
+			board := len(game.Community)
+
+			first, second, third := game.Players[0], game.Players[1], game.Players[2]
+			if messages := game.Discard(first.User, first.Cards[:2]); len(first.Cards) != 3 {
+				t.Errorf("expected discarding two cards to be rejected, got %v", messages)
+			}
+			if messages := game.Discard(first.User, second.Cards[:1]); len(first.Cards) != 3 {
+				t.Errorf("expected discarding someone else's card to be rejected, got %v", messages)
+			}
+
+			kept := first.Cards[1:]
+			game.Discard(first.User, first.Cards[:1])
+			if len(first.Cards) != 2 || first.Cards[0] != kept[0] || first.Cards[1] != kept[1] {
+				t.Errorf("expected %s to keep %v, got %v", first.Name, kept, first.Cards)
+			}
+			if messages := game.Discard(first.User, first.Cards[:1]); len(first.Cards) != 2 {
+				t.Errorf("expected discarding twice to be rejected, got %v", messages)
+			}
+
+			// The street isn't dealt until everyone has discarded
+			game.Discard(second.User, second.Cards[2:])
+			if len(game.Community) != board || game.GetState() != Discarding {
+				t.Errorf("expected the game to wait for %s to discard", third.Name)
+			}
+			game.Discard(third.User, third.Cards[1:2])
+			if len(game.Community) == board || game.GetState() != HandsDealt {
+				t.Errorf("expected the street to be dealt after everyone discarded")
+			}
+		})
+	}
+}
@@ -13,14 +13,38 @@ type Player struct {
 	Balance int
 	// The discord user associated with the player
 	User *discordgo.User
-	// The player's hole cards
+	// The player's face-down hole cards
 	Cards []Card
+	// The player's face-up cards, in games like stud
+	UpCards []Card
 	// How many chips the player has bet this round
 	CurBet int
 	// Whether the player has placed a bet yet this round
 	PlacedBet bool
+	// The bet the player last acted on this round
+	ActedOn int
 	// The player's display name
 	Name string
+	// The number of the seat the player sits in at the table
+	Seat int
+	// Whether the player is waiting for the big blind before being dealt in
+	Waiting bool
+	// Whether the player will post a blind to be dealt in on the next hand
+	Posting bool
+	// Whether the player is sitting out, and isn't dealt in
+	SittingOut bool
+	// Whether the player missed the big blind while sitting out
+	MissedBig bool
+	// Whether the player missed the small blind while sitting out
+	MissedSmall bool
+	// The number of orbits the player has sat out for
+	OrbitsOut int
+	// Whether the player is leaving the table once the hand is over
+	Leaving bool
+	// Whether the player mucks their hand at showdown when it can't win
+	Mucking bool
+	// The seconds the player has left to use once their time to act runs out
+	TimeBank int
 }
 
 // Returns the amount of money that can be bet by the player
@@ -36,6 +60,14 @@ func (p *Player) Bet(newAmount int) int {
 	return moneyLost
 }
 
+// Deals the player back in, once they've paid the blinds they owe
+func (p *Player) ReturnToPlay() {
+	p.Waiting = false
+	p.Posting = false
+	p.MissedBig = false
+	p.MissedSmall = false
+}
+
 // Pays the blind amount and returns the amount paid
 func (p *Player) PayBlind(blind int) int {
 	p.CurBet = util.Min(p.Balance, blind)
@@ -43,9 +75,25 @@ func (p *Player) PayBlind(blind int) int {
 	return p.CurBet
 }
 
+// Returns every card that the player holds, face-down and face-up
+func (p *Player) AllCards() []Card {
+	cards := make([]Card, 0, len(p.Cards)+len(p.UpCards))
+	cards = append(cards, p.Cards...)
+	return append(cards, p.UpCards...)
+}
+
 func (p *Player) PrintHand() string {
-	cardsStr := make([]string, len(p.Cards))
-	for i, card := range p.Cards {
+	return printCards(p.AllCards())
+}
+
+// Returns the player's face-up cards
+func (p *Player) PrintUpCards() string {
+	return printCards(p.UpCards)
+}
+
+func printCards(cards []Card) string {
+	cardsStr := make([]string, len(cards))
+	for i, card := range cards {
 		cardsStr[i] = card.String()
 	}
 	return strings.Join(cardsStr, " ")
@@ -1,6 +1,7 @@
 package Bot
 
 import (
+	"fmt"
 	"go-poker-bot/Bot/util"
 	"math"
 )
@@ -41,12 +42,19 @@ func NewPot(players map[*Player]struct{}) Pot {
 }
 
 // Returns which players win this pot, based on the given community cards
-func (p Pot) GetWinners(community []Card, bestHandFunc BestHandFunc) []*Player {
+func (p Pot) GetWinners(community []Card, bestHandFunc BestHandFunc) ([]*Player, error) {
 	var winners []*Player
 	var bestHand Hand
 
 	for player := range p.Players {
-		hand := bestHandFunc(community, player.Cards)
+		hand, err := bestHandFunc(community, player.AllCards())
+		if err != nil {
+			return nil, fmt.Errorf("%s's hand: %w", player.Name, err)
+		}
+		// Players without a qualifying hand can't win
+		if hand.Rank == 0 {
+			continue
+		}
 		if bestHand.Rank == 0 || bestHand.Less(hand) {
 			winners = []*Player{player}
 			bestHand = hand
@@ -54,7 +62,7 @@ func (p Pot) GetWinners(community []Card, bestHandFunc BestHandFunc) []*Player {
 			winners = append(winners, player)
 		}
 	}
-	return winners
+	return winners, nil
 }
 
 // Returns a new side pot, for when the bet overflows what can be contained
@@ -82,8 +90,13 @@ type PotManager struct {
 	// List of side pots in the game
 	// If nobody's all-in, there should only be one pot
 	// Higher-priced pots are towards the end of the list
-	Pots    []Pot
-	LastBet int
+	Pots []Pot
+	// The size of the last full bet or raise this round, which the next
+	// raise has to at least match
+	LastRaise int
+	// The bet to match after the last full bet or raise this round. Players
+	// who have already acted on it can only raise again after a full raise
+	FullRaiseBet int
 }
 
 func NewPotManager() PotManager {
@@ -100,6 +113,8 @@ func (pm *PotManager) NewHand(players []*Player) {
 		playerSet[player] = struct{}{}
 	}
 	pm.Pots = []Pot{NewPot(playerSet)}
+	pm.LastRaise = 0
+	pm.FullRaiseBet = 0
 }
 
 // Returns the current bet to be matched
@@ -122,7 +137,11 @@ func (pm PotManager) Value() int {
 
 // Increases the current bet to a new given amount
 func (pm *PotManager) IncreaseBet(newAmount int) {
+	// Side pots made earlier in the round already hold part of the bet
 	accumulatedBet := 0
+	for _, pot := range pm.Pots[:len(pm.Pots)-1] {
+		accumulatedBet += pot.CurBet
+	}
 	for pm.Pots[len(pm.Pots)-1].MaxBet < newAmount {
 		pm.Pots[len(pm.Pots)-1].CurBet = pm.Pots[len(pm.Pots)-1].MaxBet - accumulatedBet
 		accumulatedBet += pm.Pots[len(pm.Pots)-1].CurBet
@@ -130,7 +149,6 @@ func (pm *PotManager) IncreaseBet(newAmount int) {
 	}
 	newBet := util.Min(pm.Pots[len(pm.Pots)-1].MaxBet, newAmount)
 	pm.Pots[len(pm.Pots)-1].CurBet = newBet - accumulatedBet
-	pm.LastBet = newAmount
 }
 
 // Returns all the players that are in the pot
@@ -161,27 +179,70 @@ func (pm *PotManager) HandleCall(player *Player) {
 		potIndex++
 	}
 	player.PlacedBet = true
+	player.ActedOn = pm.CurBet()
 }
 
-// Handles a player raising the current bet to a given amount
+// Handles a player raising the current bet by a given amount. Only a raise at
+// least as big as the last full bet or raise reopens the betting
 func (pm *PotManager) HandleRaise(player *Player, newAmount int) {
 	pm.IncreaseBet(pm.CurBet() + newAmount)
+	if newAmount >= pm.LastRaise {
+		pm.LastRaise = newAmount
+		pm.FullRaiseBet = pm.CurBet()
+	}
 	pm.HandleCall(player)
 }
 
+// Returns whether the player is allowed to raise, which they can do if they
+// haven't acted yet this round or if there's been a full raise since they did
+func (pm PotManager) CanRaise(player *Player) bool {
+	return !player.PlacedBet || player.ActedOn < pm.FullRaiseBet
+}
+
 // Pays the initial blinds for the player, returning whether they were
 // forced to go all-in by the blind
 func (pm *PotManager) PayBlind(player *Player, blind int) bool {
 	pm.IncreaseBet(blind)
+	pm.LastRaise = util.Max(pm.LastRaise, blind)
 	pm.HandleCall(player)
 	player.PlacedBet = false
 	return player.Balance == 0
 }
 
+// Collects an ante from each of the players as dead money, which doesn't count
+// towards anyone's bet. Players who can't cover the ante go all in, and the
+// rest of the antes go into a side pot that they can't win
+func (pm *PotManager) PayAntes(players []*Player, ante int) {
+	pm.IncreaseBet(ante)
+	for _, player := range players {
+		pm.HandleCall(player)
+	}
+
+	// Close the antes off like a finished round of betting
+	pm.NextRound()
+	for _, player := range players {
+		player.PlacedBet = false
+		player.CurBet = 0
+	}
+}
+
+// Adds dead money from a player straight into the main pot, without counting
+// towards their bet or making any side pots
+func (pm *PotManager) PayDead(player *Player, amount int) {
+	amount = util.Min(amount, player.Balance)
+	player.Balance -= amount
+	pm.Pots[0].Amount += amount
+
+	// The player can't bet the dead money, so a short stack caps the pot lower
+	last := &pm.Pots[len(pm.Pots)-1]
+	if _, ok := last.Players[player]; ok {
+		last.MaxBet = util.Min(last.MaxBet, player.MaxBet())
+	}
+}
+
 // Returns whether the betting round is over
 func (pm PotManager) RoundOver() bool {
 	if pm.BettingOver() {
-		pm.LastBet = 0
 		return true
 	}
 	for player := range pm.Pots[0].Players {
@@ -192,7 +253,6 @@ func (pm PotManager) RoundOver() bool {
 			return false
 		}
 	}
-	pm.LastBet = 0
 	return true
 }
 
@@ -213,26 +273,145 @@ func (pm PotManager) BettingOver() bool {
 	return true
 }
 
-// Returns the winners of the pot, and the amounts that they won
-func (pm PotManager) GetWinners(sharedCards []Card, bestHandFunc BestHandFunc) map[*Player]int {
-	winners := make(map[*Player]int)
+// Splits every pot into equal shares, one for each time the board is run,
+// with any odd chips going to the earlier boards
+func (pm PotManager) Split(n int) []PotManager {
+	shares := make([]PotManager, n)
+	for i := range shares {
+		shares[i].Pots = make([]Pot, len(pm.Pots))
+		for j, pot := range pm.Pots {
+			amount := pot.Amount / n
+			if i < pot.Amount%n {
+				amount++
+			}
+			pot.Amount = amount
+			shares[i].Pots[j] = pot
+		}
+	}
+	return shares
+}
+
+// Winnings is how much a player won at showdown, from each half of the pots
+type Winnings struct {
+	High int
+	Low  int
+	// How many of the chips won were odd chips left over from splitting a pot
+	OddChips int
+}
+
+// Returns the total amount won
+func (w Winnings) Total() int {
+	return w.High + w.Low
+}
+
+// Returns the winners of the pot, and the amounts that they won. In split-pot
+// games, each pot is split between the best high and best low hands, with the
+// high hands winning the whole pot if nobody qualifies for low. When a pot
+// can't be split evenly, the odd chips go to the tied winners in seat order,
+// which starts from the first seat to the left of the button
+func (pm PotManager) GetWinners(sharedCards []Card, highHandFunc BestHandFunc, lowHandFunc BestHandFunc, seatOrder []*Player) (map[*Player]Winnings, error) {
+	winners := make(map[*Player]Winnings)
+
 	for _, pot := range pm.Pots {
-		potWinners := pot.GetWinners(sharedCards, bestHandFunc)
-		if len(potWinners) == 0 {
+		highWinners, err := pot.GetWinners(sharedCards, highHandFunc)
+		if err != nil {
+			return nil, err
+		}
+		if len(highWinners) == 0 {
 			continue
 		}
-		potWon := pot.Amount / len(potWinners)
-		if potWon > 0 {
-			for _, winner := range potWinners {
-				winners[winner] += potWon
+
+		var lowWinners []*Player
+		if lowHandFunc != nil {
+			lowWinners, err = pot.GetWinners(sharedCards, lowHandFunc)
+			if err != nil {
+				return nil, err
 			}
 		}
+
+		highAmount := pot.Amount
+		if len(lowWinners) > 0 {
+			// The odd chip from splitting the pot in half goes to the high hand
+			lowAmount := pot.Amount / 2
+			highAmount -= lowAmount
+			award(winners, lowWinners, lowAmount, true, seatOrder)
+		}
+		award(winners, highWinners, highAmount, false, seatOrder)
+	}
+
+	for winner, w := range winners {
+		if w.Total() == 0 {
+			delete(winners, winner)
+		}
+	}
+	return winners, nil
+}
+
+// Chop splits every pot evenly between the players in it, for when the
+// winners can't be worked out
+func (pm PotManager) Chop(seatOrder []*Player) map[*Player]Winnings {
+	winners := make(map[*Player]Winnings)
+	for _, pot := range pm.Pots {
+		var players []*Player
+		for player := range pot.Players {
+			players = append(players, player)
+		}
+		if len(players) > 0 {
+			award(winners, players, pot.Amount, false, seatOrder)
+		}
 	}
 	return winners
 }
 
+// Splits an amount between the winners of a pot, giving any odd chips out in
+// seat order
+func award(winners map[*Player]Winnings, potWinners []*Player, amount int, low bool, seatOrder []*Player) {
+	share := amount / len(potWinners)
+	oddChips := amount % len(potWinners)
+	for _, winner := range inSeatOrder(potWinners, seatOrder) {
+		w := winners[winner]
+		won := share
+		if oddChips > 0 {
+			won++
+			oddChips--
+			w.OddChips++
+		}
+		if low {
+			w.Low += won
+		} else {
+			w.High += won
+		}
+		winners[winner] = w
+	}
+}
+
+// Returns the players sorted by their position in the seat order
+func inSeatOrder(players []*Player, seatOrder []*Player) []*Player {
+	included := make(map[*Player]bool)
+	for _, player := range players {
+		included[player] = true
+	}
+
+	sorted := make([]*Player, 0, len(players))
+	for _, player := range seatOrder {
+		if included[player] {
+			sorted = append(sorted, player)
+			delete(included, player)
+		}
+	}
+	// Anyone missing from the seat order goes last
+	for _, player := range players {
+		if included[player] {
+			sorted = append(sorted, player)
+		}
+	}
+	return sorted
+}
+
 // Advances to the next round of betting
 func (pm *PotManager) NextRound() {
+	pm.LastRaise = 0
+	pm.FullRaiseBet = 0
 	for i := range pm.Pots {
 		pm.Pots[i].CurBet = 0
 		pm.Pots[i].MaxBet = 0
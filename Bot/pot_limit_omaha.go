@@ -1,7 +1,7 @@
 package Bot
 
 import (
-	"go-poker-bot/Bot/util"
+	"fmt"
 )
 
 // NewPotLimitOmaha creates a new Pot Limit Omaha game
@@ -9,52 +9,106 @@ func NewPotLimitOmaha() PokerType {
 	suits := []string{Spade, Heart, Diamond, Club}
 	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
 
-	deck := NewDeck(suits, ranks)
 	return PokerType{
 		GameType: PotLimitOmahaType,
-		Deck:     deck,
+		Deck:     NewDeck(suits, ranks),
 		BestHand: OmahaBestHand,
-		DealHand: func() []Card {
-			return deck.Deal(4)
-		},
+		Streets:  boardStreets(4),
 		String: func() string {
 			return "Pot Limit Omaha"
 		},
-		MaxBet: func(player *Player, pm *PotManager) int {
-			// 3x last bet + previous pot - player's current bet that round
-			return util.Min(
-				player.MaxBet(),
-				(3*pm.LastBet)+(pm.Value()-pm.LastBet)-player.CurBet,
-			)
-		},
+		Limit: PotLimit,
+	}
+}
+
+// NewPotLimitOmahaHiLo creates a new Pot Limit Omaha Hi-Lo (eight or better)
+// game, where the pot is split between the best high and low hands
+func NewPotLimitOmahaHiLo() PokerType {
+	pt := NewPotLimitOmaha()
+	pt.GameType = PotLimitOmahaHiLoType
+	pt.LowHand = OmahaLowHand
+	pt.String = func() string {
+		return "Pot Limit Omaha Hi-Lo"
+	}
+	return pt
+}
+
+// NewPotLimitOmaha5 creates a new 5-card Pot Limit Omaha game, where each
+// player gets five hole cards but still has to use exactly two of them
+func NewPotLimitOmaha5() PokerType {
+	pt := NewPotLimitOmaha()
+	pt.GameType = PotLimitOmaha5Type
+	pt.Streets = boardStreets(5)
+	pt.String = func() string {
+		return "5-Card Pot Limit Omaha"
+	}
+	return pt
+}
+
+// NewPotLimitOmaha6 creates a new 6-card Pot Limit Omaha game
+func NewPotLimitOmaha6() PokerType {
+	pt := NewPotLimitOmaha()
+	pt.GameType = PotLimitOmaha6Type
+	pt.Streets = boardStreets(6)
+	pt.String = func() string {
+		return "6-Card Pot Limit Omaha"
 	}
+	return pt
 }
 
-// Returns the best possible 5-card hand that can be made from the five
-// community cards and a player's four hole cards, using exactly 2 hole cards
-// and exactly 3 community cards
-func OmahaBestHand(community []Card, hole []Card) Hand {
-	if len(hole) != 4 {
-		panic("Omaha requires exactly 4 hole cards")
-	}
-	if len(community) != 5 {
-		panic("Omaha requires exactly 5 community cards")
-	}
-
-	// Generate all possible combinations of 2 hole cards
-	var best Hand
-	for holeCards := range util.Combinations(hole, 2) {
-		// For each combination of 2 hole cards, try all combinations of 3 community cards
-		for commCards := range util.Combinations(community, 3) {
-			// Combine the selected cards
-			handCards := append(holeCards, commCards...)
-			hand := NewHand(handCards)
-
-			// Update best hand if this is better
-			if best.Less(hand) {
-				best = hand
+// NewBigO creates a new Big O game, which is 5-card Pot Limit Omaha Hi-Lo
+func NewBigO() PokerType {
+	pt := NewPotLimitOmahaHiLo()
+	pt.GameType = BigOType
+	pt.Streets = boardStreets(5)
+	pt.String = func() string {
+		return "Big O"
+	}
+	return pt
+}
+
+// Returns an error unless an Omaha hand can be made out of the cards, using
+// exactly 2 hole cards and exactly 3 community cards
+func checkOmahaCards(community []Card, hole []Card) error {
+	if len(hole) < 2 || len(hole) > maxEvalCards {
+		return fmt.Errorf("an Omaha hand needs between 2 and %d hole cards, got %d", maxEvalCards, len(hole))
+	}
+	if len(community) < 3 || len(community) > maxEvalCards {
+		return fmt.Errorf("an Omaha hand needs between 3 and %d community cards, got %d", maxEvalCards, len(community))
+	}
+	return nil
+}
+
+// Returns the best possible 5-card hand that can be made from the community
+// cards and a player's hole cards, using exactly 2 hole cards and exactly 3
+// community cards. This works for any number of hole cards, so it covers
+// 5-card and 6-card Omaha as well as partial boards
+func OmahaBestHand(community []Card, hole []Card) (Hand, error) {
+	if err := checkOmahaCards(community, hole); err != nil {
+		return Hand{}, err
+	}
+
+	holeCodes := encodeCards(hole)
+	commCodes := encodeCards(community)
+
+	// Score every combination of 2 hole cards with 3 community cards
+	var best HandScore
+	var bestHole, bestComm []int
+	for _, h := range combos[len(hole)][2] {
+		for _, c := range combos[len(community)][3] {
+			score := standardEvaluator.eval5(
+				holeCodes[h[0]], holeCodes[h[1]],
+				commCodes[c[0]], commCodes[c[1]], commCodes[c[2]],
+			)
+			if score > best {
+				best = score
+				bestHole, bestComm = h, c
 			}
 		}
 	}
-	return best
+
+	return NewHand([]Card{
+		hole[bestHole[0]], hole[bestHole[1]],
+		community[bestComm[0]], community[bestComm[1]], community[bestComm[2]],
+	}), nil
 }
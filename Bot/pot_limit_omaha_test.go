@@ -81,12 +81,81 @@ func TestOmahaHandComparison(t *testing.T) {
 			},
 			winner: 1,
 		},
+		{
+			name: "Six-High Straight Beats Wheel",
+			community: []Card{
+				{Suit: Spade, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+				{Suit: Spade, Rank: "Q"},
+			},
+			hole1: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "2"},
+				{Suit: Diamond, Rank: "9"},
+				{Suit: Club, Rank: "9"},
+			},
+			hole2: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Heart, Rank: "6"},
+				{Suit: Diamond, Rank: "J"},
+				{Suit: Club, Rank: "J"},
+			},
+			winner: 2,
+		},
+		{
+			name: "Steel Wheel Beats Four of a Kind",
+			community: []Card{
+				{Suit: Heart, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Heart, Rank: "5"},
+				{Suit: Club, Rank: "5"},
+				{Suit: Spade, Rank: "Q"},
+			},
+			hole1: []Card{
+				{Suit: Heart, Rank: "A"},
+				{Suit: Heart, Rank: "2"},
+				{Suit: Diamond, Rank: "9"},
+				{Suit: Club, Rank: "9"},
+			},
+			hole2: []Card{
+				{Suit: Spade, Rank: "5"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Diamond, Rank: "J"},
+				{Suit: Club, Rank: "J"},
+			},
+			winner: 1,
+		},
+		{
+			name: "Wheels Tie",
+			community: []Card{
+				{Suit: Spade, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+				{Suit: Spade, Rank: "Q"},
+			},
+			hole1: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "2"},
+				{Suit: Diamond, Rank: "9"},
+				{Suit: Club, Rank: "9"},
+			},
+			hole2: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Club, Rank: "2"},
+				{Suit: Diamond, Rank: "J"},
+				{Suit: Club, Rank: "J"},
+			},
+			winner: 0,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hand1 := OmahaBestHand(tt.community, tt.hole1)
-			hand2 := OmahaBestHand(tt.community, tt.hole2)
+			hand1 := mustBestHand(t, OmahaBestHand, tt.community, tt.hole1)
+			hand2 := mustBestHand(t, OmahaBestHand, tt.community, tt.hole2)
 
 			if tt.winner == 1 {
 				if hand1.Less(hand2) {
@@ -115,3 +184,163 @@ func TestOmahaHandComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestOmahaLowHand(t *testing.T) {
+	tests := []struct {
+		name      string
+		community []Card
+		hole      []Card
+		expected  string
+	}{
+		{
+			name: "Wheel Is the Best Low",
+			community: []Card{
+				{Suit: Spade, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+				{Suit: Spade, Rank: "Q"},
+			},
+			hole: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "2"},
+				{Suit: Diamond, Rank: "9"},
+				{Suit: Club, Rank: "9"},
+			},
+			expected: "5-4 low",
+		},
+		{
+			name: "Must Use Two Hole Cards",
+			community: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "2"},
+				{Suit: Diamond, Rank: "3"},
+				{Suit: Club, Rank: "4"},
+				{Suit: Spade, Rank: "5"},
+			},
+			hole: []Card{
+				{Suit: Spade, Rank: "8"},
+				{Suit: Heart, Rank: "K"},
+				{Suit: Diamond, Rank: "K"},
+				{Suit: Club, Rank: "Q"},
+			},
+			expected: "",
+		},
+		{
+			name: "Paired Hole Cards Don't Count Twice",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Heart, Rank: "6"},
+				{Suit: Diamond, Rank: "7"},
+				{Suit: Club, Rank: "K"},
+				{Suit: Spade, Rank: "Q"},
+			},
+			hole: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "A"},
+				{Suit: Diamond, Rank: "2"},
+				{Suit: Club, Rank: "8"},
+			},
+			expected: "8-7 low",
+		},
+		{
+			name: "Nine Doesn't Qualify",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Heart, Rank: "6"},
+				{Suit: Diamond, Rank: "9"},
+				{Suit: Club, Rank: "K"},
+				{Suit: Spade, Rank: "Q"},
+			},
+			hole: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "3"},
+				{Suit: Diamond, Rank: "J"},
+				{Suit: Club, Rank: "J"},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand := mustBestHand(t, OmahaLowHand, tt.community, tt.hole)
+			if tt.expected == "" {
+				if hand.Rank != 0 {
+					t.Errorf("expected no low, got %v", hand)
+				}
+				return
+			}
+			if hand.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, hand.String())
+			}
+		})
+	}
+}
+
+func TestOmahaBestHandMoreHoleCards(t *testing.T) {
+	tests := []struct {
+		name      string
+		community string
+		hole      string
+		expected  string
+	}{
+		{name: "Five Hole Cards", community: "Ks7s4s8h9d", hole: "AsQs2d3dKd", expected: "ace-high flush"},
+		{name: "Six Hole Cards", community: "Ks7s4h8h9d", hole: "2s3dKdKc5h6h", expected: "nine-high straight"},
+		{name: "Must Use Two Hole Cards", community: "AsKsQsJs2d", hole: "10s3d4c5h6h", expected: "ace high"},
+		{name: "Partial Board", community: "Ks7s4s", hole: "AsQs2d3dKd", expected: "ace-high flush"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand := mustBestHand(t, OmahaBestHand, mustParseCards(t, tt.community), mustParseCards(t, tt.hole))
+			if hand.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, hand.String())
+			}
+		})
+	}
+}
+
+func TestOmahaBestHandErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		community string
+		hole      string
+	}{
+		{name: "One Hole Card", community: "Ks7s4s8h9d", hole: "As"},
+		{name: "Two Community Cards", community: "Ks7s", hole: "AsQs2d3d"},
+		{name: "No Cards", community: "", hole: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			community, hole := mustParseCards(t, tt.community), mustParseCards(t, tt.hole)
+			if _, err := OmahaBestHand(community, hole); err == nil {
+				t.Errorf("expected an error from OmahaBestHand")
+			}
+			if _, err := OmahaLowHand(community, hole); err == nil {
+				t.Errorf("expected an error from OmahaLowHand")
+			}
+		})
+	}
+}
+
+func TestOmahaMaxPlayers(t *testing.T) {
+	tests := []struct {
+		pokerType PokerType
+		expected  int
+	}{
+		{pokerType: NewPotLimitOmaha(), expected: 11},
+		{pokerType: NewPotLimitOmaha5(), expected: 9},
+		{pokerType: NewPotLimitOmaha6(), expected: 7},
+		{pokerType: NewBigO(), expected: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pokerType.String(), func(t *testing.T) {
+			if got := tt.pokerType.MaxPlayers(); got != tt.expected {
+				t.Errorf("expected at most %d players, got %d", tt.expected, got)
+			}
+		})
+	}
+}
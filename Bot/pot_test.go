@@ -0,0 +1,145 @@
+package Bot
+
+import (
+	"testing"
+)
+
+// Creates a pot manager holding a single pot of the given amount, contested
+// by players holding the given hole cards
+func newTestPot(t *testing.T, amount int, hands ...string) (PotManager, []*Player) {
+	t.Helper()
+	players := make([]*Player, len(hands))
+	for i, hand := range hands {
+		players[i] = &Player{Name: string(rune('A' + i)), Cards: mustParseCards(t, hand)}
+	}
+	pm := NewPotManager()
+	pm.NewHand(players)
+	pm.Pots[0].Amount = amount
+	return pm, players
+}
+
+func TestHiLoSplitWinners(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    string
+		hands    []string
+		amount   int
+		expected []Winnings
+	}{
+		{
+			name:     "Scoop Without a Qualifying Low",
+			board:    "KsQh9d9c2s",
+			hands:    []string{"AsAh3d4d", "KdKc5h6h"},
+			amount:   100,
+			expected: []Winnings{{}, {High: 100}},
+		},
+		{
+			name:     "Scoop Both Halves",
+			board:    "3s4h5dKcQs",
+			hands:    []string{"As2h9d9c", "KdKhJc10c"},
+			amount:   100,
+			expected: []Winnings{{High: 50, Low: 50}, {}},
+		},
+		{
+			name:     "High and Low Split",
+			board:    "3s4h8dKcQs",
+			hands:    []string{"As2h9d9c", "KdKhJc10c"},
+			amount:   101,
+			expected: []Winnings{{Low: 50}, {High: 51}},
+		},
+		{
+			name:     "Quartered Low",
+			board:    "3s4h8dKcKs",
+			hands:    []string{"As2hKdJc", "Ad2d9c9h", "QhQd10s10d"},
+			amount:   120,
+			expected: []Winnings{{High: 60, Low: 30}, {Low: 30}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm, players := newTestPot(t, tt.amount, tt.hands...)
+			winners, err := pm.GetWinners(mustParseCards(t, tt.board), OmahaBestHand, OmahaLowHand, players)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, player := range players {
+				if winners[player] != tt.expected[i] {
+					t.Errorf("player %s: expected %+v, got %+v", player.Name, tt.expected[i], winners[player])
+				}
+			}
+		})
+	}
+}
+
+func TestOddChipDistribution(t *testing.T) {
+	tests := []struct {
+		name      string
+		board     string
+		hands     []string
+		amount    int
+		seatOrder []int
+		expected  []Winnings
+	}{
+		{
+			name:      "Odd Chip to First Seat Left of Button",
+			board:     "AsKdQhJc10s",
+			hands:     []string{"2s3d", "2h3c", "4h5c"},
+			amount:    100,
+			seatOrder: []int{1, 2, 0},
+			expected:  []Winnings{{High: 33}, {High: 34, OddChips: 1}, {High: 33}},
+		},
+		{
+			name:      "Two Odd Chips",
+			board:     "AsKdQhJc10s",
+			hands:     []string{"2s3d", "2h3c", "4h5c"},
+			amount:    101,
+			seatOrder: []int{2, 0, 1},
+			expected:  []Winnings{{High: 34, OddChips: 1}, {High: 33}, {High: 34, OddChips: 1}},
+		},
+		{
+			name:      "Even Split Has No Odd Chip",
+			board:     "AsKdQhJc10s",
+			hands:     []string{"2s3d", "2h3c"},
+			amount:    100,
+			seatOrder: []int{1, 0},
+			expected:  []Winnings{{High: 50}, {High: 50}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm, players := newTestPot(t, tt.amount, tt.hands...)
+			seatOrder := make([]*Player, len(tt.seatOrder))
+			for i, idx := range tt.seatOrder {
+				seatOrder[i] = players[idx]
+			}
+			winners, err := pm.GetWinners(mustParseCards(t, tt.board), TexasHoldemBestHand, nil, seatOrder)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			total := 0
+			for i, player := range players {
+				if winners[player] != tt.expected[i] {
+					t.Errorf("player %s: expected %+v, got %+v", player.Name, tt.expected[i], winners[player])
+				}
+				total += winners[player].Total()
+			}
+			if total != tt.amount {
+				t.Errorf("expected $%d to be awarded, got $%d", tt.amount, total)
+			}
+		})
+	}
+}
+
+func TestSplitPots(t *testing.T) {
+	pm := PotManager{Pots: []Pot{{Amount: 7}, {Amount: 4}}}
+	shares := pm.Split(2)
+	if shares[0].Pots[0].Amount != 4 || shares[1].Pots[0].Amount != 3 {
+		t.Errorf("expected the odd chip to go to the first board, got $%d and $%d", shares[0].Pots[0].Amount, shares[1].Pots[0].Amount)
+	}
+	if shares[0].Pots[1].Amount != 2 || shares[1].Pots[1].Amount != 2 {
+		t.Errorf("expected the side pot to be split evenly")
+	}
+}
@@ -0,0 +1,66 @@
+package Bot
+
+// NewRazz creates a new Razz game, which is played like Seven-Card Stud but
+// the lowest ace-to-five hand wins the whole pot
+func NewRazz() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	return PokerType{
+		GameType: RazzType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: RazzBestHand,
+		Streets:  studStreets(),
+		BringIn:  razzBringIn,
+		Limit:    FixedLimit,
+		Showing:  razzShowing,
+		String: func() string {
+			return "Razz"
+		},
+	}
+}
+
+// Returns the best ace-to-five low hand out of a player's seven cards, along
+// with a shared community card if the deck ran out. Unlike in split-pot
+// games, there's no need to qualify
+func RazzBestHand(community []Card, hole []Card) (Hand, error) {
+	allCards := make([]Card, 0, len(community)+len(hole))
+	allCards = append(allCards, community...)
+	allCards = append(allCards, hole...)
+	if err := checkHandSize(len(allCards)); err != nil {
+		return Hand{}, err
+	}
+
+	var best Hand
+	for _, combo := range combos[len(allCards)][5] {
+		hand := NewLowHand([]Card{
+			allCards[combo[0]], allCards[combo[1]], allCards[combo[2]],
+			allCards[combo[3]], allCards[combo[4]],
+		})
+		if best.Rank == 0 || best.Less(hand) {
+			best = hand
+		}
+	}
+	return best, nil
+}
+
+// The highest face-up card pays the bring-in, with aces counting as low and
+// spades being the highest suit when ranks tie
+func razzBringIn(doors []Card) int {
+	highest := 0
+	for i, card := range doors {
+		if card.LowValue() > doors[highest].LowValue() ||
+			(card.LowValue() == doors[highest].LowValue() && suitOrder[card.Suit] > suitOrder[doors[highest].Suit]) {
+			highest = i
+		}
+	}
+	return highest
+}
+
+// Scores face-up cards by how good a low they show, so the lowest board acts
+// first
+func razzShowing(up []Card) int {
+	cards := make([]Card, len(up))
+	copy(cards, up)
+	return int(NewLowHand(cards).Score)
+}
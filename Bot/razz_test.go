@@ -0,0 +1,56 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestRazzBestHand(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		expected string
+	}{
+		{name: "Wheel", cards: "As2h3d4c5sKsKh", expected: "5-4 low"},
+		{name: "Straights and Flushes Don't Count", cards: "2s3s4s5s7sKhQd", expected: "7-5 low"},
+		{name: "Pairs Are Avoided", cards: "2s2h3d3c4s5h8d", expected: "8-5 low"},
+		{name: "Forced to Pair", cards: "2s2h3d3c4s4h8d", expected: "pair of deuces"},
+		{name: "Forced to Two Pair", cards: "2s2h2d3c3s3h4d", expected: "two pair, threes and deuces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustBestHand(t, RazzBestHand, nil, mustParseCards(t, tt.cards)).String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRazzBringIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		doors    string
+		expected int
+	}{
+		{name: "Highest Rank", doors: "Ks2h9d", expected: 0},
+		{name: "Aces Are Low", doors: "As3h2d", expected: 1},
+		{name: "Spades Break Ties", doors: "KhKsKc", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := razzBringIn(mustParseCards(t, tt.doors)); got != tt.expected {
+				t.Errorf("expected player %d to bring it in, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRazzShowing(t *testing.T) {
+	if razzShowing(mustParseCards(t, "As2h")) <= razzShowing(mustParseCards(t, "3s2d")) {
+		t.Errorf("expected A-2 to show a better low than 3-2")
+	}
+	if razzShowing(mustParseCards(t, "Ks9h")) <= razzShowing(mustParseCards(t, "2s2d")) {
+		t.Errorf("expected K-9 to show a better low than a pair")
+	}
+}
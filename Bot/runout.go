@@ -0,0 +1,112 @@
+package Bot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Returns whether the rest of the hand can be run twice, which needs betting
+// to be over before the last street with only community cards left to deal
+func (g *Game) canRunTwice() bool {
+	if g.Street+1 >= len(g.Type.Streets) {
+		return false
+	}
+	for _, street := range g.Type.Streets[g.Street+1:] {
+		if street.Down > 0 || street.Up > 0 || street.Draw || street.Discard > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the players in the pot who haven't agreed to run it twice yet
+func (g *Game) waitingToRunTwice() []*Player {
+	var waiting []*Player
+	for _, player := range g.playersInPot() {
+		if !g.RunTwice[player] {
+			waiting = append(waiting, player)
+		}
+	}
+	return waiting
+}
+
+// Returns the names of the players, separated by commas
+func playerNames(players []*Player) string {
+	names := make([]string, len(players))
+	for i, player := range players {
+		names[i] = player.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Deals the rest of the board twice from the same deck, returning both boards
+func (g *Game) runTwice() [][]Card {
+	street := g.Street
+	community := g.Community
+	boards := make([][]Card, 2)
+	for i := range boards {
+		g.Community = slices.Clone(community)
+		for g.Street = street + 1; g.Street < len(g.Type.Streets); g.Street++ {
+			g.dealStreet(g.Type.Streets[g.Street])
+		}
+		boards[i] = g.Community
+	}
+	g.Street = len(g.Type.Streets) - 1
+	return boards
+}
+
+// RunItTwice records that the user agrees to deal the rest of the board twice
+// if betting ends early. Once everyone in the pot has agreed after betting is
+// over, the boards are dealt
+func (g *Game) RunItTwice(user *discordgo.User) []string {
+	if g.Type.BoardSize() == 0 {
+		return []string{"You can only run it twice in games with community cards!"}
+	}
+	player := g.GetPlayer(user)
+	if !slices.Contains(g.playersInPot(), player) {
+		return []string{"You're not in the hand!"}
+	}
+	if g.RunTwice[player] {
+		return []string{"You've already agreed to run it twice!"}
+	}
+
+	if g.RunTwice == nil {
+		g.RunTwice = make(map[*Player]bool)
+	}
+	g.RunTwice[player] = true
+
+	if g.State != ChoosingRunouts {
+		return []string{fmt.Sprintf("%s wants to run it twice if betting ends early.", player.Name)}
+	}
+	messages := []string{fmt.Sprintf("%s agrees to run it twice.", player.Name)}
+	return append(messages, g.Showdown()...)
+}
+
+// RunOnce has the user turn down running it twice, so the board is dealt once
+func (g *Game) RunOnce(user *discordgo.User) []string {
+	if g.State != ChoosingRunouts {
+		return []string{"Nobody is waiting to run it twice!"}
+	}
+	player := g.GetPlayer(user)
+	if !slices.Contains(g.playersInPot(), player) {
+		return []string{"You're not in the hand!"}
+	}
+
+	messages := []string{fmt.Sprintf("%s wants to run it once.", player.Name)}
+	return append(messages, g.runOnce()...)
+}
+
+// Turns down running it twice, and deals the rest of the board once
+func (g *Game) runOnce() []string {
+	g.RunTwice = nil
+	g.State = HandsDealt
+	for g.Street+1 < len(g.Type.Streets) {
+		g.Street++
+		g.dealStreet(g.Type.Streets[g.Street])
+		g.recordStreet()
+	}
+	return g.revealHandsOn([][]Card{g.Community})
+}
@@ -0,0 +1,83 @@
+package Bot
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRunItTwice(t *testing.T) {
+	tests := []struct {
+		name     string
+		preagree bool
+		agree    bool
+		boards   int
+	}{
+		{name: "Everyone Agrees", preagree: true, agree: true, boards: 2},
+		{name: "Agree After Betting", preagree: false, agree: true, boards: 2},
+		{name: "Run Once", preagree: true, agree: false, boards: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 2)
+			dealer, other := game.Players[0], game.Players[1]
+			game.DealHands()
+			if tt.preagree {
+				game.RunItTwice(other.User)
+			}
+
+			game.AllIn()
+			messages := game.Call()
+			if game.GetState() != ChoosingRunouts {
+				t.Fatalf("expected to wait for everyone to agree, got %v", messages)
+			}
+			if tt.agree {
+				if !tt.preagree {
+					game.RunItTwice(other.User)
+				}
+				messages = game.RunItTwice(dealer.User)
+			} else {
+				messages = game.RunOnce(dealer.User)
+			}
+
+			if game.GetState() == ChoosingRunouts || game.HandInProgress() {
+				t.Fatalf("expected the hand to be over, got %v", messages)
+			}
+			output := strings.Join(messages, "\n")
+			if runTwice := strings.Contains(output, "**Board 2:**"); runTwice != (tt.boards == 2) {
+				t.Errorf("expected %d boards, got %v", tt.boards, messages)
+			}
+			total := 0
+			for _, player := range game.Players {
+				total += player.Balance
+			}
+			if total != 200 {
+				t.Errorf("expected $200 to be awarded between the players, got $%d", total)
+			}
+		})
+	}
+}
+
+func TestRunItTwiceNeedsBoard(t *testing.T) {
+	game := newTestGame("stud", 2)
+	game.DealHands()
+	game.RunItTwice(game.Players[0].User)
+	if len(game.RunTwice) > 0 {
+		t.Errorf("expected running it twice to be refused without community cards")
+	}
+}
+
+func TestRunTwiceBoardsDiffer(t *testing.T) {
+	game := newTestGame("holdem", 2)
+	game.DealHands()
+	boards := game.runTwice()
+	if len(boards[0]) != 5 || len(boards[1]) != 5 {
+		t.Fatalf("expected two full boards, got %v", boards)
+	}
+	for _, card := range boards[0] {
+		if slices.Contains(boards[1], card) {
+			t.Errorf("expected the boards to be dealt from the same deck, but %s is on both", card)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package Bot
+
+import (
+	"fmt"
+	"slices"
+
+	"go-poker-bot/Bot/util"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Positions holds the seats of the button and blinds for a hand. The button
+// and small blind can be dead, in which case nobody in the hand sits there
+type Positions struct {
+	Button     int
+	SmallBlind int
+	BigBlind   int
+}
+
+// Returns the next seat number, after every seat that's been used
+func (g *Game) nextSeat() int {
+	seat := 0
+	if g.LastPositions != nil {
+		seat = util.Max(g.LastPositions.Button, util.Max(g.LastPositions.SmallBlind, g.LastPositions.BigBlind)) + 1
+	}
+	for _, player := range g.Players {
+		seat = util.Max(seat, player.Seat+1)
+	}
+	return seat
+}
+
+// Returns the player sitting in the seat, or nil if it's empty
+func (g *Game) playerInSeat(seat int) *Player {
+	for _, player := range g.Players {
+		if player.Seat == seat {
+			return player
+		}
+	}
+	return nil
+}
+
+// Returns the player in the seat if they're in the hand, or nil otherwise
+func (g *Game) inHandInSeat(seat int) *Player {
+	for _, player := range g.InHand {
+		if player.Seat == seat {
+			return player
+		}
+	}
+	return nil
+}
+
+// Returns the first player after the seat, going around the table, who
+// matches the filter
+func (g *Game) nextSeated(seat int, filter func(*Player) bool) *Player {
+	var first *Player
+	for _, player := range g.Players {
+		if !filter(player) {
+			continue
+		}
+		if player.Seat > seat {
+			return player
+		}
+		if first == nil {
+			first = player
+		}
+	}
+	return first
+}
+
+// Returns the first player before the seat, going back around the table, who
+// matches the filter
+func (g *Game) prevSeated(seat int, filter func(*Player) bool) *Player {
+	var last *Player
+	for i := len(g.Players) - 1; i >= 0; i-- {
+		player := g.Players[i]
+		if !filter(player) {
+			continue
+		}
+		if player.Seat < seat {
+			return player
+		}
+		if last == nil {
+			last = player
+		}
+	}
+	return last
+}
+
+// Returns the index of the player sitting in the seat, or the closest player
+// to its right if it's empty
+func (g *Game) seatIndex(seat int) int {
+	for i := len(g.Players) - 1; i >= 0; i-- {
+		if g.Players[i].Seat <= seat {
+			return i
+		}
+	}
+	return len(g.Players) - 1
+}
+
+// Returns whether a seat comes strictly between two others, going around the
+// table from the first
+func seatBetween(seat, from, to int) bool {
+	if from < to {
+		return from < seat && seat < to
+	}
+	return seat > from || seat < to
+}
+
+// Returns whether the player can be given the big blind
+func canTakeBlind(player *Player) bool {
+	return !player.SittingOut
+}
+
+// Returns whether the player is dealt in, given who has the big blind. Players
+// waiting to come in are only dealt in once they get the big blind or post
+func (g *Game) dealtIn(player *Player, big *Player) bool {
+	if player.SittingOut {
+		return false
+	}
+	if g.Type.BringIn != nil {
+		return true
+	}
+	return !player.Waiting || player.Posting || player == big
+}
+
+// Returns the positions for the next hand, and whether there are enough
+// players to deal it. The big blind moves on to the next player every hand,
+// with the small blind and button following behind it, even when that leaves
+// them in empty seats
+func (g *Game) nextPositions() (Positions, bool) {
+	if len(g.Players) == 0 {
+		return Positions{}, false
+	}
+
+	var big *Player
+	var pos Positions
+	if g.LastPositions == nil {
+		// The first hand starts from the dealer
+		button := g.Players[g.DealerIndex%len(g.Players)]
+		small := g.nextSeated(button.Seat, canTakeBlind)
+		if g.countDealtIn(nil) == 2 {
+			small = button
+		}
+		if small == nil {
+			return Positions{}, false
+		}
+		big = g.nextSeated(small.Seat, canTakeBlind)
+		if big == nil {
+			return Positions{}, false
+		}
+		pos = Positions{Button: button.Seat, SmallBlind: small.Seat, BigBlind: big.Seat}
+	} else {
+		last := *g.LastPositions
+		big = g.nextSeated(last.BigBlind, canTakeBlind)
+		if big == nil {
+			return Positions{}, false
+		}
+		pos = Positions{Button: last.SmallBlind, SmallBlind: last.BigBlind, BigBlind: big.Seat}
+	}
+
+	switch g.countDealtIn(big) {
+	case 0, 1:
+		return Positions{}, false
+	case 2:
+		// In heads-up games, the dealer plays the small blind
+		for _, player := range g.Players {
+			if player != big && g.dealtIn(player, big) {
+				pos.Button, pos.SmallBlind = player.Seat, player.Seat
+			}
+		}
+	default:
+		// The button can't end up on the big blind when someone coming
+		// back sits between the blinds, so it goes to the player before the
+		// small blind instead
+		if pos.Button == pos.BigBlind {
+			pos.Button = g.prevSeated(pos.SmallBlind, func(p *Player) bool {
+				return g.dealtIn(p, big)
+			}).Seat
+		}
+	}
+	return pos, true
+}
+
+// Returns how many players would be dealt in with the given big blind
+func (g *Game) countDealtIn(big *Player) int {
+	count := 0
+	for _, player := range g.Players {
+		if g.dealtIn(player, big) {
+			count++
+		}
+	}
+	return count
+}
+
+// Moves the button on by dead button rules, recording the blinds missed by
+// anyone sitting out, and returns the players dealt into the hand. Players
+// who have sat out for too long are removed, with messages saying so
+func (g *Game) seatHand(pos Positions) ([]*Player, []string) {
+	messages := []string{}
+	if g.LastPositions != nil {
+		last := *g.LastPositions
+		blinds := g.Type.BringIn == nil
+		for _, player := range slices.Clone(g.Players) {
+			if !player.SittingOut {
+				continue
+			}
+			// The big blind passing a player marks an orbit sat out
+			if seatBetween(player.Seat, last.BigBlind, pos.BigBlind) {
+				player.OrbitsOut++
+				player.MissedBig = player.MissedBig || blinds
+			}
+			if player.Seat == pos.SmallBlind {
+				player.MissedSmall = player.MissedSmall || blinds
+			}
+			if limit := g.Options.SitOutOrbits; limit > 0 && player.OrbitsOut >= limit {
+				messages = append(messages, fmt.Sprintf("%s has been removed after sitting out for %d orbits, leaving with $%d.",
+					player.Name, player.OrbitsOut, player.Balance))
+				g.removePlayer(player)
+			}
+		}
+	}
+
+	g.LastPositions = &pos
+	g.DealerIndex = g.seatIndex(pos.Button)
+
+	big := g.playerInSeat(pos.BigBlind)
+	var dealt []*Player
+	for _, player := range g.Players {
+		if g.dealtIn(player, big) {
+			dealt = append(dealt, player)
+		}
+	}
+	return dealt, messages
+}
+
+// Takes a player out of their seat with their balance, along with anything
+// they'd set up for the next hand
+func (g *Game) removePlayer(player *Player) {
+	if i := slices.Index(g.Players, player); i != -1 {
+		g.Players = slices.Delete(g.Players, i, i+1)
+		// Keep the dealer on the same player
+		if i < g.DealerIndex {
+			g.DealerIndex--
+		}
+		if g.DealerIndex >= len(g.Players) {
+			g.DealerIndex = 0
+		}
+	}
+	g.LeaveHand(player)
+	if g.Straddler == player {
+		g.Straddler = nil
+	}
+	g.Left = append(g.Left, player)
+	g.recordLedger(player, player.Balance, "Cashed out")
+}
+
+// Returns whether the player was dealt into the hand, even if they've since
+// folded or gone all in
+func wasDealtIn(player *Player) bool {
+	return len(player.AllCards()) > 0
+}
+
+// Leave takes the user out of the game with their balance. Players dealt into
+// the hand in progress leave once it's over
+func (g *Game) Leave(user *discordgo.User) []string {
+	player := g.GetPlayer(user)
+	if g.HandInProgress() && wasDealtIn(player) {
+		if player.Leaving {
+			return []string{"You're already leaving after this hand!"}
+		}
+		player.Leaving = true
+		return []string{fmt.Sprintf("%s will leave the table after this hand.", player.Name)}
+	}
+
+	g.removePlayer(player)
+	messages := []string{fmt.Sprintf("%s has left the table with $%d.", player.Name, player.Balance)}
+	if g.State != Waiting && len(g.Players) < 2 {
+		messages = append(messages, "There aren't enough players left to keep playing.")
+		messages = append(messages, g.EndGame()...)
+	}
+	return messages
+}
+
+// Wraps up a hand, letting anyone who's leaving go, and moves on to the next
+// hand unless there aren't enough players left
+func (g *Game) finishHand() []string {
+	messages := []string{}
+	for _, player := range slices.Clone(g.Players) {
+		if player.Leaving {
+			g.removePlayer(player)
+			messages = append(messages, fmt.Sprintf("%s has left the table with $%d.", player.Name, player.Balance))
+		}
+	}
+
+	if len(g.Players) < 2 {
+		messages = append(messages, "There aren't enough players left to keep playing.")
+		return append(messages, g.EndGame()...)
+	}
+
+	g.State = NoHands
+	g.NextDealer()
+	return append(messages, g.StatusBetweenRounds()...)
+}
+
+// SitOut has the user keep their seat and balance without being dealt in,
+// from the next hand on
+func (g *Game) SitOut(user *discordgo.User) []string {
+	player := g.GetPlayer(user)
+	if player.SittingOut {
+		return []string{"You're already sitting out!"}
+	}
+
+	player.SittingOut = true
+	player.Posting = false
+	player.OrbitsOut = 0
+	if g.Straddler == player {
+		g.Straddler = nil
+	}
+	return []string{fmt.Sprintf("%s is sitting out from the next hand.", player.Name)}
+}
+
+// Back deals the user back in after sitting out. Players who missed blinds
+// wait for the big blind, or post what they missed
+func (g *Game) Back(user *discordgo.User) []string {
+	player := g.GetPlayer(user)
+	if !player.SittingOut {
+		return []string{"You're not sitting out!"}
+	}
+
+	player.SittingOut = false
+	player.OrbitsOut = 0
+	if g.Type.BringIn == nil && (player.MissedBig || player.MissedSmall) {
+		player.Waiting = true
+		live, dead := g.catchUpBlinds(player)
+		return []string{fmt.Sprintf("%s is back, and will be dealt in at the big blind, "+
+			"or can message !post to be dealt in on the next hand for $%d.", player.Name, live+dead)}
+	}
+	player.ReturnToPlay()
+	return []string{fmt.Sprintf("%s is back, and will be dealt in on the next hand.", player.Name)}
+}
+
+// Returns the index in the hand of the first player to the left of the button
+func (g *Game) firstLeftOfButton() int {
+	for _, player := range g.SeatOrder() {
+		for i, p := range g.InHand {
+			if p == player {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// Returns the live and dead blinds that a waiting player has to post to be
+// dealt in before the big blind reaches them. New players post a big blind,
+// and players who missed blinds make up for the ones they missed
+func (g *Game) catchUpBlinds(player *Player) (int, int) {
+	live, dead := 0, 0
+	if player.MissedBig || !player.MissedSmall {
+		live = g.Options.BigBlind
+	}
+	if player.MissedBig || player.MissedSmall {
+		dead = g.Options.SmallBlind
+	}
+	return live, dead
+}
+
+// Post has a waiting player post a blind to be dealt in on the next hand,
+// instead of waiting for the big blind
+func (g *Game) Post(user *discordgo.User) []string {
+	player := g.GetPlayer(user)
+	if !player.Waiting || player.SittingOut {
+		return []string{"You don't need to post to be dealt in!"}
+	}
+	if g.Type.BringIn != nil {
+		return []string{"You'll be dealt in on the next hand."}
+	}
+	if player.Posting {
+		return []string{"You're already posting on the next hand!"}
+	}
+
+	live, dead := g.catchUpBlinds(player)
+	if player.Balance <= live+dead {
+		return []string{"You don't have enough money to post!"}
+	}
+
+	player.Posting = true
+	return []string{fmt.Sprintf("%s will post $%d to be dealt in on the next hand.", player.Name, live+dead)}
+}
+
+// Collects the blinds posted by players coming into the hand early. The big
+// blind they post is live, and counts towards their bet
+func (g *Game) PayPosts() []string {
+	messages := []string{}
+	for _, player := range g.InHand {
+		if !player.Posting {
+			continue
+		}
+		live, dead := g.catchUpBlinds(player)
+		if live > 0 {
+			g.PotManager.PayBlind(player, live)
+		}
+		if dead > 0 {
+			g.PotManager.PayDead(player, dead)
+		}
+		switch {
+		case live > 0 && dead > 0:
+			g.History.Post(player, "small & big blinds", live+dead)
+		case live > 0:
+			g.History.Post(player, "big blind", live)
+		default:
+			g.History.Post(player, "small blind", dead)
+		}
+		player.ReturnToPlay()
+		messages = append(messages, fmt.Sprintf("%s has posted $%d to be dealt in.", player.Name, live+dead))
+	}
+	return messages
+}
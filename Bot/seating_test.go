@@ -0,0 +1,300 @@
+package Bot
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Has everyone fold until the hand is over
+func foldHand(game *Game) {
+	for game.HandInProgress() {
+		game.Fold()
+	}
+}
+
+func TestDeadButton(t *testing.T) {
+	tests := []struct {
+		name    string
+		removed int
+		button  int
+		small   int
+		big     int
+		dealer  int
+	}{
+		// The button is dead when last hand's small blind leaves
+		{name: "Dead Button", removed: 2, button: 2, small: 3, big: 0, dealer: 1},
+		// The small blind is dead when last hand's big blind leaves
+		{name: "Dead Small Blind", removed: 3, button: 2, small: 3, big: 0, dealer: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 4)
+			game.DealHands()
+			foldHand(game)
+			game.DealHands()
+			if pos := *game.LastPositions; pos != (Positions{Button: 1, SmallBlind: 2, BigBlind: 3}) {
+				t.Fatalf("expected the button and blinds to move on one seat, got %+v", pos)
+			}
+			foldHand(game)
+
+			removed := game.Players[tt.removed]
+			game.Players = append(game.Players[:tt.removed], game.Players[tt.removed+1:]...)
+			game.NextDealer()
+			game.DealHands()
+
+			want := Positions{Button: tt.button, SmallBlind: tt.small, BigBlind: tt.big}
+			if pos := *game.LastPositions; pos != want {
+				t.Errorf("expected positions %+v, got %+v", want, pos)
+			}
+			if dealer := game.GetDealer(); dealer.Seat != tt.dealer {
+				t.Errorf("expected the player in seat %d to deal, got seat %d", tt.dealer, dealer.Seat)
+			}
+
+			// Nobody pays the small blind twice, and the big blind moves on
+			small, big := game.blindPlayers()
+			if small == removed || (small != nil && small.CurBet != 1) {
+				t.Errorf("expected a dead or live small blind, got %v", small)
+			}
+			if big.Seat != tt.big || big.CurBet != 2 {
+				t.Errorf("expected seat %d to pay the big blind", tt.big)
+			}
+		})
+	}
+}
+
+func TestNewPlayerWaits(t *testing.T) {
+	tests := []struct {
+		name  string
+		post  bool
+		dealt bool
+	}{
+		{name: "Waits For Big Blind", post: false, dealt: false},
+		{name: "Posts", post: true, dealt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 4)
+			game.DealHands()
+			foldHand(game)
+
+			user := &discordgo.User{ID: "E"}
+			game.AddPlayer(user, "E")
+			newPlayer := game.GetPlayer(user)
+			newPlayer.Balance = 100
+			if !newPlayer.Waiting || newPlayer.Seat != 4 {
+				t.Fatalf("expected the new player to wait in seat 4")
+			}
+			if tt.post {
+				game.Post(user)
+			}
+
+			game.DealHands()
+			if dealt := slices.Contains(game.InHand, newPlayer); dealt != tt.dealt {
+				t.Fatalf("expected the new player being dealt in to be %t", tt.dealt)
+			}
+			if tt.post && (newPlayer.CurBet != 2 || newPlayer.Balance != 98 || newPlayer.Waiting) {
+				t.Errorf("expected the new player to post a live big blind, got a bet of $%d", newPlayer.CurBet)
+			}
+			foldHand(game)
+
+			// The big blind reaches them on the next hand
+			game.DealHands()
+			if _, big := game.blindPlayers(); big != newPlayer || newPlayer.Waiting {
+				t.Errorf("expected the new player to be dealt in on the big blind")
+			}
+		})
+	}
+}
+
+func TestMissedBlinds(t *testing.T) {
+	game := newTestGame("holdem", 4)
+	game.DealHands()
+	foldHand(game)
+
+	// The big blind skips a player sitting out
+	away := game.Players[3]
+	away.SittingOut = true
+	game.DealHands()
+	if _, big := game.blindPlayers(); big != game.Players[0] {
+		t.Fatalf("expected the big blind to skip the player sitting out")
+	}
+	if !away.MissedBig || slices.Contains(game.InHand, away) {
+		t.Fatalf("expected the player sitting out to miss the big blind")
+	}
+	foldHand(game)
+
+	// Coming back means posting the big blind live and the small blind dead
+	away.SittingOut = false
+	away.Waiting = true
+	if live, dead := game.catchUpBlinds(away); live != 2 || dead != 1 {
+		t.Errorf("expected to owe $2 live and $1 dead, got $%d and $%d", live, dead)
+	}
+	game.Post(away.User)
+	game.DealHands()
+	if away.CurBet != 2 || away.Balance != 97 || away.MissedBig {
+		t.Errorf("expected the missed blinds to be paid, got a bet of $%d and $%d left", away.CurBet, away.Balance)
+	}
+	if value := game.PotManager.Value(); value != 6 {
+		t.Errorf("expected $6 in the pot with the dead small blind, got $%d", value)
+	}
+}
+
+func TestMissedBlindsShortStack(t *testing.T) {
+	game := newTestGame("holdem", 4)
+	for _, player := range game.Players {
+		player.Balance = 1000
+	}
+	game.DealHands()
+	foldHand(game)
+
+	away := game.Players[3]
+	away.SittingOut = true
+	game.DealHands()
+	foldHand(game)
+
+	// Coming back with $10 leaves $7 after posting, and only $2 of the posts
+	// count towards their bet
+	away.SittingOut = false
+	away.Waiting = true
+	away.Balance = 10
+	game.Post(away.User)
+	game.DealHands()
+	total := totalChips(game)
+	game.Raise(20)
+	for game.HandInProgress() && game.Street == 0 {
+		game.Call()
+	}
+	if away.Balance != 0 {
+		t.Fatalf("expected %s to be all in, has $%d", away.Name, away.Balance)
+	}
+	if after := totalChips(game); after != total {
+		t.Errorf("expected $%d in chips, got $%d", total, after)
+	}
+	pots := game.PotManager.Pots
+	if len(pots) < 2 || pots[0].Amount != 37 {
+		t.Fatalf("expected a $37 main pot and a side pot, got %+v", pots)
+	}
+	if _, ok := pots[1].Players[away]; ok {
+		t.Errorf("expected %s not to be able to win the side pot", away.Name)
+	}
+}
+
+func TestSitOut(t *testing.T) {
+	game := newTestGame("holdem", 4)
+	game.DealHands()
+	foldHand(game)
+
+	away := game.Players[3]
+	balance := away.Balance
+	game.SitOut(away.User)
+	game.DealHands()
+	if slices.Contains(game.InHand, away) || away.Balance != balance {
+		t.Fatalf("expected %s to keep their balance without being dealt in", away.Name)
+	}
+	if _, big := game.blindPlayers(); big == away {
+		t.Errorf("expected the big blind to skip %s", away.Name)
+	}
+	foldHand(game)
+
+	// Having missed the big blind, they wait for it to come back round
+	game.Back(away.User)
+	if away.SittingOut || !away.Waiting {
+		t.Errorf("expected %s to wait for the big blind after coming back", away.Name)
+	}
+	if messages := game.Back(away.User); messages[0] != "You're not sitting out!" {
+		t.Errorf("expected coming back twice to be rejected, got %v", messages)
+	}
+}
+
+func TestEveryoneSitsOutFirstHand(t *testing.T) {
+	game := newTestGame("holdem", 2)
+	for _, player := range game.Players {
+		player.SittingOut = true
+	}
+	game.DealHands()
+	if game.HandInProgress() || len(game.InHand) != 0 {
+		t.Errorf("expected no hand to be dealt with everyone sitting out")
+	}
+}
+
+func TestSitOutRemoval(t *testing.T) {
+	tests := []struct {
+		name    string
+		orbits  int
+		removed bool
+	}{
+		{name: "No Limit", orbits: 0, removed: false},
+		{name: "Removed After Limit", orbits: 1, removed: true},
+		{name: "Under Limit", orbits: 2, removed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 4)
+			game.SetOption([]string{"sitout", strconv.Itoa(tt.orbits)})
+			game.DealHands()
+			foldHand(game)
+
+			away := game.Players[3]
+			game.SitOut(away.User)
+			messages := game.DealHands()
+			if removed := !slices.Contains(game.Players, away); removed != tt.removed {
+				t.Errorf("expected %s being removed to be %t, got %v", away.Name, tt.removed, messages)
+			}
+			if len(game.InHand) != 3 {
+				t.Errorf("expected the hand to be dealt to the other 3 players, got %d", len(game.InHand))
+			}
+		})
+	}
+}
+
+func TestLeave(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		midHand bool
+		ended   bool
+	}{
+		{name: "Between Hands", players: 3, midHand: false, ended: false},
+		{name: "After The Hand", players: 3, midHand: true, ended: false},
+		{name: "Ends Game", players: 2, midHand: false, ended: true},
+		{name: "Ends Game After The Hand", players: 2, midHand: true, ended: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", tt.players)
+			game.DealHands()
+			if !tt.midHand {
+				foldHand(game)
+			}
+
+			leaving := game.Players[0]
+			game.Leave(leaving.User)
+			if tt.midHand {
+				if !slices.Contains(game.Players, leaving) {
+					t.Fatalf("expected %s to stay until the hand is over", leaving.Name)
+				}
+				foldHand(game)
+			}
+
+			if slices.Contains(game.Players, leaving) {
+				t.Errorf("expected %s to have left the table", leaving.Name)
+			}
+			if !slices.Contains(game.Left, leaving) {
+				t.Errorf("expected %s's balance to be recorded", leaving.Name)
+			}
+			if ended := game.State == NoGame; ended != tt.ended {
+				t.Errorf("expected the game ending to be %t, got state %v", tt.ended, game.State)
+			}
+			if !tt.ended && game.DealerIndex >= len(game.Players) {
+				t.Errorf("expected a valid dealer, got index %d", game.DealerIndex)
+			}
+		})
+	}
+}
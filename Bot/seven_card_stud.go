@@ -0,0 +1,95 @@
+package Bot
+
+import "sort"
+
+// NewSevenCardStud creates a new Seven-Card Stud game, where each player is
+// dealt their own seven cards over five streets, four of them face-up
+func NewSevenCardStud() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	return PokerType{
+		GameType: SevenCardStudType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: SevenCardStudBestHand,
+		Streets:  studStreets(),
+		BringIn:  studBringIn,
+		Limit:    FixedLimit,
+		Showing:  studShowing,
+		String: func() string {
+			return "Seven-Card Stud"
+		},
+	}
+}
+
+// The streets of a stud game, where each player gets two cards face-down and
+// one face-up, then three more face-up and a final card face-down
+func studStreets() []Street {
+	return []Street{
+		{Name: "third street", Down: 2, Up: 1},
+		{Name: "fourth street", Up: 1},
+		{Name: "fifth street", Up: 1},
+		{Name: "sixth street", Up: 1},
+		{Name: "seventh street", Down: 1},
+	}
+}
+
+// Returns the best possible 5-card hand out of a player's seven cards, along
+// with a shared community card if the deck ran out
+func SevenCardStudBestHand(community []Card, hole []Card) (Hand, error) {
+	return standardEvaluator.bestHoldemHand(community, hole)
+}
+
+// The lowest face-up card pays the bring-in, with clubs being the lowest suit
+// when ranks tie
+func studBringIn(doors []Card) int {
+	lowest := 0
+	for i, card := range doors {
+		if card.Value() < doors[lowest].Value() ||
+			(card.Value() == doors[lowest].Value() && suitOrder[card.Suit] < suitOrder[doors[lowest].Suit]) {
+			lowest = i
+		}
+	}
+	return lowest
+}
+
+// Scores face-up cards by the pairs, trips and quads they show, then by their
+// highest cards. Straights and flushes don't count until all five cards are out
+func studShowing(up []Card) int {
+	counts := make(map[int]int)
+	for _, card := range up {
+		counts[card.Value()]++
+	}
+
+	var groups [][2]int // (count, value) pairs
+	for value, count := range counts {
+		groups = append(groups, [2]int{count, value})
+	}
+	// Larger groups are more significant, then higher values
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i][0] != groups[j][0] {
+			return groups[i][0] > groups[j][0]
+		}
+		return groups[i][1] > groups[j][1]
+	})
+
+	score := 0
+	switch {
+	case len(groups) == 0:
+	case groups[0][0] == 4:
+		score = 4
+	case groups[0][0] == 3:
+		score = 3
+	case groups[0][0] == 2 && len(groups) > 1 && groups[1][0] == 2:
+		score = 2
+	case groups[0][0] == 2:
+		score = 1
+	}
+	for i := 0; i < 4; i++ {
+		score <<= 4
+		if i < len(groups) {
+			score |= groups[i][1] + 1
+		}
+	}
+	return score
+}
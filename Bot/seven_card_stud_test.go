@@ -0,0 +1,116 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestStudBringIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		doors    string
+		expected int
+	}{
+		{name: "Lowest Rank", doors: "Ks2h9d", expected: 1},
+		{name: "Aces Are High", doors: "As3hQd", expected: 1},
+		{name: "Clubs Break Ties", doors: "2s2h2c2d", expected: 2},
+		{name: "Diamonds Below Hearts", doors: "4h4d", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := studBringIn(mustParseCards(t, tt.doors)); got != tt.expected {
+				t.Errorf("expected player %d to bring it in, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestStudShowing(t *testing.T) {
+	tests := []struct {
+		name   string
+		better string
+		worse  string
+	}{
+		{name: "High Card", better: "Ah2c", worse: "KhQc"},
+		{name: "Pair Beats High Card", better: "2h2c", worse: "AhKc"},
+		{name: "Higher Pair", better: "9h9c3d", worse: "8h8cAd"},
+		{name: "Pair Kicker", better: "9h9cKd", worse: "9s9dQd"},
+		{name: "Two Pair Beats Pair", better: "3h3c2d2s", worse: "AhAcKdQs"},
+		{name: "Trips Beat Two Pair", better: "3h3c3d", worse: "AhAcKdKs"},
+		{name: "Quads Beat Trips", better: "2h2c2d2s", worse: "AhAcAdKs"},
+		{name: "Straights Don't Count", better: "2h2c", worse: "5h6h7h8h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := studShowing(mustParseCards(t, tt.better))
+			worse := studShowing(mustParseCards(t, tt.worse))
+			if better <= worse {
+				t.Errorf("expected %s to show better than %s", tt.better, tt.worse)
+			}
+		})
+	}
+}
+
+func TestStudDealing(t *testing.T) {
+	game := newTestGame("stud", 8)
+	game.DealHands()
+
+	if !game.TakeNewHoleCards() {
+		t.Errorf("expected new hole cards after dealing")
+	}
+	for _, player := range game.Players {
+		if len(player.Cards) != 2 || len(player.UpCards) != 1 {
+			t.Fatalf("%s has %d down and %d up cards on third street", player.Name, len(player.Cards), len(player.UpCards))
+		}
+	}
+
+	// Check every street down, with eight players the deck runs out before
+	// seventh street and a community card is dealt instead
+	for game.Street < len(game.Type.Streets)-1 {
+		game.NextRound()
+	}
+
+	if len(game.Community) != 1 {
+		t.Errorf("expected a single community card, got %d", len(game.Community))
+	}
+	seen := make(map[Card]bool)
+	for _, card := range game.Community {
+		seen[card] = true
+	}
+	for _, player := range game.Players {
+		if len(player.AllCards()) != 6 {
+			t.Errorf("%s has %d cards, expected 6", player.Name, len(player.AllCards()))
+		}
+		for _, card := range player.AllCards() {
+			if seen[card] {
+				t.Errorf("%s was dealt twice", card)
+			}
+			seen[card] = true
+		}
+	}
+}
+
+func TestStudCompletesBringIn(t *testing.T) {
+	game := newTestGame("stud", 3)
+	game.DealHands()
+	if bet := game.PotManager.CurBet(); bet != 1 {
+		t.Fatalf("expected a $1 bring-in, got $%d", bet)
+	}
+
+	// The first raise completes the bring-in to the $2 small bet, and counts
+	// as the first of the four bets allowed
+	game.Raise(1)
+	if bet := game.PotManager.CurBet(); bet != 2 {
+		t.Errorf("expected the bring-in to be completed to $2, got $%d", bet)
+	}
+	game.Raise(1)
+	game.Raise(1)
+	game.Raise(1)
+	if bet := game.PotManager.CurBet(); bet != 8 {
+		t.Errorf("expected three $2 raises after completing, got a bet of $%d", bet)
+	}
+	if messages := game.Raise(1); messages[0] != "Betting is capped for this round - you can only call or fold!" {
+		t.Errorf("expected betting to be capped, got %v", messages)
+	}
+}
@@ -0,0 +1,25 @@
+package Bot
+
+// NewShortDeckHoldem creates a new Short Deck (6+) Hold'em game, played
+// with the twos through fives removed from the deck
+func NewShortDeckHoldem() PokerType {
+	suits := []string{Spade, Heart, Diamond, Club}
+	ranks := []string{"6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+	return PokerType{
+		GameType: ShortDeckHoldemType,
+		Deck:     NewDeck(suits, ranks),
+		BestHand: ShortDeckBestHand,
+		Streets:  boardStreets(2),
+		String: func() string {
+			return "Short Deck Hold'em"
+		},
+	}
+}
+
+// Returns the best possible 5-card hand that can be made from the five
+// community cards and a player's two hole cards, where a flush beats a full
+// house and A-6-7-8-9 is the lowest straight
+func ShortDeckBestHand(community []Card, hole []Card) (Hand, error) {
+	return shortDeckEvaluator.bestHoldemHand(community, hole)
+}
@@ -0,0 +1,132 @@
+package Bot
+
+import (
+	"testing"
+)
+
+func TestShortDeckHandComparison(t *testing.T) {
+	tests := []struct {
+		name      string
+		community []Card
+		hole1     []Card
+		hole2     []Card
+		winner    int
+	}{
+		{
+			name: "Flush Beats Full House",
+			community: []Card{
+				{Suit: Spade, Rank: "K"},
+				{Suit: Spade, Rank: "9"},
+				{Suit: Heart, Rank: "9"},
+				{Suit: Diamond, Rank: "6"},
+				{Suit: Spade, Rank: "7"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "K"},
+				{Suit: Diamond, Rank: "K"},
+			},
+			hole2: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Spade, Rank: "8"},
+			},
+			winner: 2,
+		},
+		{
+			name: "Six-Low Straight Beats Ace-Low Straight",
+			community: []Card{
+				{Suit: Spade, Rank: "7"},
+				{Suit: Club, Rank: "8"},
+				{Suit: Heart, Rank: "9"},
+				{Suit: Diamond, Rank: "K"},
+				{Suit: Club, Rank: "Q"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "6"},
+			},
+			hole2: []Card{
+				{Suit: Club, Rank: "10"},
+				{Suit: Heart, Rank: "6"},
+			},
+			winner: 2,
+		},
+		{
+			name: "Ace-Low Straight Beats Three of a Kind",
+			community: []Card{
+				{Suit: Spade, Rank: "7"},
+				{Suit: Club, Rank: "8"},
+				{Suit: Heart, Rank: "9"},
+				{Suit: Diamond, Rank: "K"},
+				{Suit: Club, Rank: "Q"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "6"},
+			},
+			hole2: []Card{
+				{Suit: Club, Rank: "K"},
+				{Suit: Heart, Rank: "K"},
+			},
+			winner: 1,
+		},
+		{
+			name: "Full House Beats Straight",
+			community: []Card{
+				{Suit: Spade, Rank: "J"},
+				{Suit: Club, Rank: "J"},
+				{Suit: Heart, Rank: "Q"},
+				{Suit: Diamond, Rank: "K"},
+				{Suit: Club, Rank: "6"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "10"},
+			},
+			hole2: []Card{
+				{Suit: Heart, Rank: "J"},
+				{Suit: Heart, Rank: "6"},
+			},
+			winner: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand1 := mustBestHand(t, ShortDeckBestHand, tt.community, tt.hole1)
+			hand2 := mustBestHand(t, ShortDeckBestHand, tt.community, tt.hole2)
+
+			if tt.winner == 1 {
+				if hand1.Less(hand2) {
+					t.Errorf("player1 should win but hand1.Less(hand2) returned true")
+					t.Logf("player1 hand: %v", hand1)
+					t.Logf("player2 hand: %v", hand2)
+				}
+			} else if tt.winner == 2 {
+				if !hand1.Less(hand2) {
+					t.Errorf("player2 should win but hand1.Less(hand2) returned false")
+					t.Logf("player1 hand: %v", hand1)
+					t.Logf("player2 hand: %v", hand2)
+				}
+			}
+		})
+	}
+}
+
+func TestShortDeckAceLowStraightName(t *testing.T) {
+	hand := mustBestHand(t, ShortDeckBestHand,
+		[]Card{
+			{Suit: Spade, Rank: "7"},
+			{Suit: Club, Rank: "8"},
+			{Suit: Heart, Rank: "9"},
+			{Suit: Diamond, Rank: "K"},
+			{Suit: Club, Rank: "Q"},
+		},
+		[]Card{
+			{Suit: Club, Rank: "A"},
+			{Suit: Heart, Rank: "6"},
+		},
+	)
+	if hand.String() != "nine-high straight" {
+		t.Errorf("expected a nine-high straight, got %q", hand.String())
+	}
+}
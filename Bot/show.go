@@ -0,0 +1,134 @@
+package Bot
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Returns the players in the pot in the order they show their hands, starting
+// with the last aggressor, or the first player to the left of the button if
+// nobody bet on the last round
+func (g *Game) showOrder() []*Player {
+	players := g.playersInPot()
+	if i := slices.Index(players, g.Aggressor); i > 0 {
+		players = append(players[i:], players[:i]...)
+	}
+	return players
+}
+
+// Reveals the hands at showdown in show order. Players who want to muck can
+// do so once their hand can't win anything against the hands already shown,
+// unless someone is all in, when every hand is turned face up
+func (g *Game) revealHands(boards [][]Card) []string {
+	messages := []string{}
+
+	allIn := false
+	for _, player := range g.playersInPot() {
+		if player.Balance == 0 {
+			allIn = true
+		}
+	}
+
+	g.History.add("*** SHOW DOWN ***")
+	var shown []*Player
+	for _, player := range g.showOrder() {
+		if player.Mucking && !allIn && len(shown) > 0 && !g.couldWin(player, shown, boards) {
+			messages = append(messages, fmt.Sprintf("%s mucks.", player.Name))
+			g.History.Muck(player)
+			g.Unshown = append(g.Unshown, player)
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s's hand: %s", player.Name, player.PrintHand()))
+		g.History.Show(player)
+		shown = append(shown, player)
+	}
+	return messages
+}
+
+// Returns whether the player could win or tie any pot on any of the boards,
+// against the hands that have already been shown
+func (g *Game) couldWin(player *Player, shown []*Player, boards [][]Card) bool {
+	for _, board := range boards {
+		for _, pot := range g.PotManager.Pots {
+			if _, ok := pot.Players[player]; !ok {
+				continue
+			}
+			if beatsShown(player, shown, pot, board, g.Type.BestHand) {
+				return true
+			}
+			if g.Type.LowHand != nil && beatsShown(player, shown, pot, board, g.Type.LowHand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns whether the player's hand is at least as good as every shown hand
+// that's in the pot
+func beatsShown(player *Player, shown []*Player, pot Pot, board []Card, bestHandFunc BestHandFunc) bool {
+	hand, err := bestHandFunc(board, player.AllCards())
+	if err != nil {
+		return true
+	}
+	if hand.Rank == 0 {
+		return false
+	}
+	for _, other := range shown {
+		if _, ok := pot.Players[other]; !ok {
+			continue
+		}
+		otherHand, err := bestHandFunc(board, other.AllCards())
+		if err == nil && otherHand.Rank != 0 && hand.Less(otherHand) {
+			return false
+		}
+	}
+	return true
+}
+
+// Deals out the rest of the board after everyone folds, so that players can
+// see what would have come without changing the result
+func (g *Game) huntRabbit() []Card {
+	count := 0
+	for _, street := range g.Type.Streets[g.Street+1:] {
+		count += street.Community
+	}
+	return g.Deck.Deal(count)
+}
+
+// RabbitHunt shows the rest of the board from the last hand
+func (g *Game) RabbitHunt() []string {
+	if len(g.Rabbit) == 0 {
+		return []string{"There's no board left to rabbit hunt!"}
+	}
+	return []string{fmt.Sprintf("The rest of the board would have been: %s", printCards(g.Rabbit))}
+}
+
+// Show has the user show their hand at showdown, or show it after the hand if
+// it wasn't shown
+func (g *Game) Show(user *discordgo.User) []string {
+	player := g.GetPlayer(user)
+	if g.HandInProgress() {
+		player.Mucking = false
+		return []string{"You'll show your hand at showdown."}
+	}
+
+	i := slices.Index(g.Unshown, player)
+	if i == -1 {
+		return []string{"You have no hand to show!"}
+	}
+	g.Unshown = slices.Delete(g.Unshown, i, i+1)
+	return []string{fmt.Sprintf("%s shows %s", player.Name, player.PrintHand())}
+}
+
+// Muck has the user muck their hand at showdown when it can't win, for the
+// rest of this hand
+func (g *Game) Muck(user *discordgo.User) []string {
+	if !g.HandInProgress() {
+		return []string{"There's no hand to muck!"}
+	}
+	g.GetPlayer(user).Mucking = true
+	return []string{"You'll muck your hand at showdown when it can't win."}
+}
@@ -0,0 +1,105 @@
+package Bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShowOrder(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.DealHands()
+	first, second, third := game.Players[0], game.Players[1], game.Players[2]
+	board := mustParseCards(t, "AsKd7c4h2s")
+	first.Cards = mustParseCards(t, "QhJs")
+	second.Cards = mustParseCards(t, "AhKh")
+	third.Cards = mustParseCards(t, "Ac2d")
+
+	tests := []struct {
+		name      string
+		aggressor *Player
+		mucking   *Player
+		want      []string
+	}{
+		{
+			name: "Left Of Button First",
+			want: []string{"B's hand", "C's hand", "A's hand"},
+		},
+		{
+			name:      "Last Aggressor First",
+			aggressor: third,
+			want:      []string{"C's hand", "A's hand", "B's hand"},
+		},
+		{
+			name:      "Beaten Hand Mucks",
+			aggressor: second,
+			mucking:   third,
+			want:      []string{"B's hand", "C mucks.", "A's hand"},
+		},
+		{
+			name:    "Can't Muck Before Beaten",
+			mucking: second,
+			want:    []string{"B's hand", "C's hand", "A's hand"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game.Aggressor = tt.aggressor
+			game.Unshown = nil
+			for _, player := range game.Players {
+				player.Mucking = player == tt.mucking
+			}
+
+			messages := game.revealHands([][]Card{board})
+			if len(messages) != len(tt.want) {
+				t.Fatalf("expected %d messages, got %v", len(tt.want), messages)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(messages[i], want) {
+					t.Errorf("expected message %d to start with %q, got %q", i, want, messages[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRabbitHunt(t *testing.T) {
+	game := newTestGame("holdem", 2)
+	dealer, other := game.Players[0], game.Players[1]
+	game.DealHands()
+	game.Fold()
+
+	if len(game.Rabbit) != 5 {
+		t.Fatalf("expected the whole board to be left to hunt, got %v", game.Rabbit)
+	}
+	if messages := game.RabbitHunt(); !strings.Contains(messages[0], game.Rabbit[0].String()) {
+		t.Errorf("expected the rest of the board to be shown, got %v", messages)
+	}
+
+	// Only the winner can show their hand, once
+	if messages := game.Show(dealer.User); messages[0] != "You have no hand to show!" {
+		t.Errorf("expected the player who folded to have nothing to show, got %v", messages)
+	}
+	if messages := game.Show(other.User); !strings.HasPrefix(messages[0], other.Name+" shows") {
+		t.Errorf("expected the winner to show their hand, got %v", messages)
+	}
+	if messages := game.Show(other.User); messages[0] != "You have no hand to show!" {
+		t.Errorf("expected the hand to only be shown once, got %v", messages)
+	}
+}
+
+func TestMuckOnlyLastsOneHand(t *testing.T) {
+	game := newTestGame("holdem", 2)
+	player := game.Players[0]
+	game.DealHands()
+	game.Muck(player.User)
+	if !player.Mucking {
+		t.Fatalf("expected %s to muck this hand", player.Name)
+	}
+	foldHand(game)
+
+	game.DealHands()
+	if player.Mucking {
+		t.Errorf("expected mucking not to carry over to the next hand")
+	}
+}
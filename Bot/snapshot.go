@@ -0,0 +1,322 @@
+package Bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The snapshot of a pot, with the players who can win it given by user ID
+type potSnapshot struct {
+	Players []string
+	CurBet  int
+	Amount  int
+	MaxBet  int
+}
+
+// The snapshot of a deck, keeping the order of the cards left in it
+type deckSnapshot struct {
+	Cards    []Card
+	All      []Card
+	Discards []Card
+}
+
+// The snapshot of a game that's saved to disk. Players are saved once, and
+// everywhere else refers to them by user ID
+type gameSnapshot struct {
+	GameType        GameType
+	Deck            deckSnapshot
+	Community       []Card
+	Street          int
+	NewHoleCards    bool
+	DrawOrder       []string
+	AwaitingDiscard []string
+	Bets            int
+	RunTwice        []string
+	Aggressor       string
+	Unshown         []string
+	Rabbit          []Card
+	Players         []*Player
+	Left            []*Player
+	State           GameState
+	DealerIndex     int
+	FirstBettor     int
+	TurnIndex       int
+	Turn            int
+	TurnTime        int
+	InHand          []string
+	Straddler       string
+	LastPositions   *Positions
+	GuildID         string
+	ChannelID       string
+	History         *HandHistory
+	Options         GameOptions
+	LastRaise       *time.Time
+	Verbose         bool
+	Pots            []potSnapshot
+	LastRaiseSize   int
+	FullRaiseBet    int
+}
+
+// Returns the user IDs of the players
+func playerIDs(players []*Player) []string {
+	ids := make([]string, len(players))
+	for i, player := range players {
+		ids[i] = player.User.ID
+	}
+	return ids
+}
+
+// Returns the user IDs of the players in the set, in a consistent order
+func playerSetIDs[V any](players map[*Player]V) []string {
+	ids := make([]string, 0, len(players))
+	for player := range players {
+		ids = append(ids, player.User.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Returns the user ID of the player, or an empty string if there's no player
+func playerID(player *Player) string {
+	if player == nil {
+		return ""
+	}
+	return player.User.ID
+}
+
+// Returns a snapshot of everything needed to carry on the game
+func (g *Game) snapshot() gameSnapshot {
+	snap := gameSnapshot{
+		GameType: g.Type.GameType,
+		Deck: deckSnapshot{
+			Cards:    g.Deck.cards,
+			All:      g.Deck.all,
+			Discards: g.Deck.discards,
+		},
+		Community:       g.Community,
+		Street:          g.Street,
+		NewHoleCards:    g.NewHoleCards,
+		DrawOrder:       playerIDs(g.DrawOrder),
+		AwaitingDiscard: playerSetIDs(g.AwaitingDiscard),
+		Bets:            g.Bets,
+		RunTwice:        playerSetIDs(g.RunTwice),
+		Aggressor:       playerID(g.Aggressor),
+		Unshown:         playerIDs(g.Unshown),
+		Rabbit:          g.Rabbit,
+		Players:         g.Players,
+		Left:            g.Left,
+		State:           g.State,
+		DealerIndex:     g.DealerIndex,
+		FirstBettor:     g.FirstBettor,
+		TurnIndex:       g.TurnIndex,
+		Turn:            g.Turn,
+		TurnTime:        g.TurnTime,
+		InHand:          playerIDs(g.InHand),
+		Straddler:       playerID(g.Straddler),
+		LastPositions:   g.LastPositions,
+		GuildID:         g.GuildID,
+		ChannelID:       g.ChannelID,
+		History:         g.History,
+		Options:         g.Options,
+		LastRaise:       g.LastRaise,
+		Verbose:         g.Verbose,
+		LastRaiseSize:   g.PotManager.LastRaise,
+		FullRaiseBet:    g.PotManager.FullRaiseBet,
+	}
+	for _, pot := range g.PotManager.Pots {
+		snap.Pots = append(snap.Pots, potSnapshot{
+			Players: playerSetIDs(pot.Players),
+			CurBet:  pot.CurBet,
+			Amount:  pot.Amount,
+			MaxBet:  pot.MaxBet,
+		})
+	}
+	return snap
+}
+
+// Rebuilds a game from its snapshot, matching up the user IDs with the players
+// they refer to
+func restoreGame(snap gameSnapshot) (*Game, error) {
+	pt, ok := NewPokerType(snap.GameType)
+	if !ok {
+		return nil, fmt.Errorf("unknown game type %d", snap.GameType)
+	}
+
+	// Players still at the table take priority over anyone with the same ID
+	// who left earlier
+	byID := make(map[string]*Player)
+	for _, player := range slices.Concat(snap.Left, snap.Players) {
+		if player.User == nil {
+			return nil, fmt.Errorf("player %s has no user", player.Name)
+		}
+		byID[player.User.ID] = player
+	}
+	var err error
+	lookup := func(id string) *Player {
+		if id == "" {
+			return nil
+		}
+		player, ok := byID[id]
+		if !ok && err == nil {
+			err = fmt.Errorf("unknown player %s", id)
+		}
+		return player
+	}
+	lookupAll := func(ids []string) []*Player {
+		players := make([]*Player, 0, len(ids))
+		for _, id := range ids {
+			if player := lookup(id); player != nil {
+				players = append(players, player)
+			}
+		}
+		return players
+	}
+	lookupSet := func(ids []string) map[*Player]bool {
+		players := make(map[*Player]bool)
+		for _, player := range lookupAll(ids) {
+			players[player] = true
+		}
+		return players
+	}
+
+	g := &Game{
+		Type: &pt,
+		Deck: Deck{
+			cards:    snap.Deck.Cards,
+			all:      snap.Deck.All,
+			discards: snap.Deck.Discards,
+		},
+		Community:       snap.Community,
+		Street:          snap.Street,
+		NewHoleCards:    snap.NewHoleCards,
+		DrawOrder:       lookupAll(snap.DrawOrder),
+		AwaitingDiscard: lookupSet(snap.AwaitingDiscard),
+		Bets:            snap.Bets,
+		RunTwice:        lookupSet(snap.RunTwice),
+		Aggressor:       lookup(snap.Aggressor),
+		Unshown:         lookupAll(snap.Unshown),
+		Rabbit:          snap.Rabbit,
+		Players:         snap.Players,
+		Left:            snap.Left,
+		State:           snap.State,
+		DealerIndex:     snap.DealerIndex,
+		FirstBettor:     snap.FirstBettor,
+		TurnIndex:       snap.TurnIndex,
+		Turn:            snap.Turn,
+		TurnTime:        snap.TurnTime,
+		InHand:          lookupAll(snap.InHand),
+		Straddler:       lookup(snap.Straddler),
+		LastPositions:   snap.LastPositions,
+		GuildID:         snap.GuildID,
+		ChannelID:       snap.ChannelID,
+		History:         snap.History,
+		Options:         snap.Options,
+		LastRaise:       snap.LastRaise,
+		Verbose:         snap.Verbose,
+		PotManager: PotManager{
+			Pots:         make([]Pot, 0, len(snap.Pots)),
+			LastRaise:    snap.LastRaiseSize,
+			FullRaiseBet: snap.FullRaiseBet,
+		},
+	}
+	if g.Players == nil {
+		g.Players = make([]*Player, 0)
+	}
+	for _, pot := range snap.Pots {
+		players := make(map[*Player]struct{})
+		for _, player := range lookupAll(pot.Players) {
+			players[player] = struct{}{}
+		}
+		g.PotManager.Pots = append(g.PotManager.Pots, Pot{
+			Players: players,
+			CurBet:  pot.CurBet,
+			Amount:  pot.Amount,
+			MaxBet:  pot.MaxBet,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Returns whether the game is worth saving, which it isn't before a game has
+// been started or after it's ended
+func (g *Game) worthSaving() bool {
+	return g.State != NoGame && (g.State != Waiting || len(g.Players) > 0)
+}
+
+// Encodes a snapshot of the game as it's saved to disk
+func encodeGame(g *Game) ([]byte, error) {
+	return json.Marshal(g.snapshot())
+}
+
+// SaveGame writes a snapshot of the game to the path
+func SaveGame(path string, g *Game) error {
+	data, err := encodeGame(g)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// Writes the data to the path through a temporary file, so a crash can't leave
+// it half written
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadGame reads the game saved at the path
+func LoadGame(path string) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap gameSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("reading game %s: %w", path, err)
+	}
+	return restoreGame(snap)
+}
+
+// LoadGames reads every game saved in the directory, keyed by the channel
+// they're played in. Games that can't be read are logged and skipped
+func LoadGames(dir string) (map[string]*Game, error) {
+	games := make(map[string]*Game)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return games, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		channelID, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		game, err := LoadGame(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			log.Printf("Skipping the saved game in %s: %v", channelID, err)
+			continue
+		}
+		games[channelID] = game
+	}
+	return games, nil
+}
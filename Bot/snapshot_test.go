@@ -0,0 +1,99 @@
+package Bot
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType string
+	}{
+		{name: "Hold'em", gameType: "holdem"},
+		{name: "Stud", gameType: "stud"},
+		{name: "Draw", gameType: "27td"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame(tt.gameType, 3)
+			game.Players[0].Balance = 20
+			game.DealHands()
+			// Save partway through a betting round, with someone all in
+			for game.GetCurrentPlayer() != game.Players[0] {
+				game.Call()
+			}
+			game.AllIn()
+
+			path := filepath.Join(t.TempDir(), "game.json")
+			if err := SaveGame(path, game); err != nil {
+				t.Fatalf("unexpected error saving the game: %v", err)
+			}
+			restored, err := LoadGame(path)
+			if err != nil {
+				t.Fatalf("unexpected error loading the game: %v", err)
+			}
+
+			// Playing the rest of the hand the same way has the same result
+			for _, g := range []*Game{game, restored} {
+				for g.HandInProgress() {
+					if g.GetState() == Drawing {
+						g.Draw(nil)
+						continue
+					}
+					g.Call()
+				}
+			}
+			for i, player := range game.Players {
+				got := restored.Players[i]
+				if got.Balance != player.Balance || !slices.Equal(got.AllCards(), player.AllCards()) {
+					t.Errorf("expected %s to end with $%d and %s, got $%d and %s",
+						player.Name, player.Balance, player.PrintHand(), got.Balance, got.PrintHand())
+				}
+			}
+			if restored.DealerIndex != game.DealerIndex || restored.State != game.State {
+				t.Errorf("expected the restored game to carry on the same way")
+			}
+		})
+	}
+}
+
+func TestLoadGamesSkipsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveGame(filepath.Join(dir, "good.json"), newTestGame("holdem", 2)); err != nil {
+		t.Fatalf("unexpected error saving the game: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	games, err := LoadGames(dir)
+	if err != nil {
+		t.Fatalf("unexpected error loading the games: %v", err)
+	}
+	if _, ok := games["good"]; !ok || len(games) != 1 {
+		t.Errorf("expected only the good game to be loaded, got %v", games)
+	}
+}
+
+func TestWorthSaving(t *testing.T) {
+	game := NewGame()
+	if game.worthSaving() {
+		t.Errorf("expected a channel without a game not to be saved")
+	}
+	game.StartNewGame()
+	if game.worthSaving() {
+		t.Errorf("expected a game nobody has joined not to be saved")
+	}
+	game = newTestGame("holdem", 2)
+	if !game.worthSaving() {
+		t.Errorf("expected a game with players to be saved")
+	}
+	game.EndGame()
+	if game.worthSaving() {
+		t.Errorf("expected a game that's ended not to be saved")
+	}
+}
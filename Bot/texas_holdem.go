@@ -1,44 +1,43 @@
 package Bot
 
-import (
-	"go-poker-bot/Bot/util"
-)
-
 // NewTexasHoldem creates a new Texas Hold'em game
 func NewTexasHoldem() PokerType {
 	suits := []string{Spade, Heart, Diamond, Club}
 	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
 
-	deck := NewDeck(suits, ranks)
 	return PokerType{
 		GameType: TexasHoldemType,
-		Deck:     deck,
+		Deck:     NewDeck(suits, ranks),
 		BestHand: TexasHoldemBestHand,
-		DealHand: func() []Card {
-			return deck.Deal(2)
-		},
+		Streets:  boardStreets(2),
 		String: func() string {
 			return "Texas Hold'em"
 		},
-		MaxBet: func(player *Player, pm *PotManager) int {
-			return player.MaxBet()
-		},
 	}
 }
 
 // Returns the best possible 5-card hand that can be made from the five
 // community cards and a player's two hole cards
-func TexasHoldemBestHand(community []Card, hole []Card) Hand {
+func TexasHoldemBestHand(community []Card, hole []Card) (Hand, error) {
+	return standardEvaluator.bestHoldemHand(community, hole)
+}
+
+// Returns the best 5-card hand out of the community cards and hole cards,
+// ranked by the evaluator
+func (e *evaluator) bestHoldemHand(community []Card, hole []Card) (Hand, error) {
 	// Combine all cards
-	allCards := append(community, hole...)
+	allCards := make([]Card, 0, len(community)+len(hole))
+	allCards = append(allCards, community...)
+	allCards = append(allCards, hole...)
+	if err := checkHandSize(len(allCards)); err != nil {
+		return Hand{}, err
+	}
 
-	// Generate all possible 5-card combinations
-	var best Hand
-	for handCards := range util.Combinations(allCards, 5) {
-		hand := NewHand(handCards)
-		if best.Rank == 0 || best.Less(hand) {
-			best = hand
-		}
+	// Score every 5-card combination, only building the best one into a hand
+	_, combo := e.best(encodeCards(allCards))
+	handCards := make([]Card, 5)
+	for i, idx := range combo {
+		handCards[i] = allCards[idx]
 	}
-	return best
+	return e.newHand(handCards), nil
 }
@@ -1000,12 +1000,107 @@ func TestPlayerHandComparison(t *testing.T) {
 			},
 			winner: 0,
 		},
+		{
+			name: "Six-High Straight Beats Wheel",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Club, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "K"},
+			},
+			hole2: []Card{
+				{Suit: Club, Rank: "6"},
+				{Suit: Heart, Rank: "9"},
+			},
+			winner: 2,
+		},
+		{
+			name: "Wheel Beats Three of a Kind",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Club, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "J"},
+			},
+			hole2: []Card{
+				{Suit: Spade, Rank: "K"},
+				{Suit: Heart, Rank: "K"},
+			},
+			winner: 1,
+		},
+		{
+			name: "Wheels Tie",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Club, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+			},
+			hole1: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "J"},
+			},
+			hole2: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "Q"},
+			},
+			winner: 0,
+		},
+		{
+			name: "Six-High Straight Flush Beats Steel Wheel",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Spade, Rank: "3"},
+				{Suit: Spade, Rank: "4"},
+				{Suit: Spade, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+			},
+			hole1: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "K"},
+			},
+			hole2: []Card{
+				{Suit: Spade, Rank: "6"},
+				{Suit: Heart, Rank: "9"},
+			},
+			winner: 2,
+		},
+		{
+			name: "Steel Wheel Beats Four of a Kind",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Spade, Rank: "3"},
+				{Suit: Spade, Rank: "4"},
+				{Suit: Spade, Rank: "5"},
+				{Suit: Club, Rank: "5"},
+			},
+			hole1: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "K"},
+			},
+			hole2: []Card{
+				{Suit: Heart, Rank: "5"},
+				{Suit: Diamond, Rank: "5"},
+			},
+			winner: 1,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hand1 := TexasHoldemBestHand(tt.community, tt.hole1)
-			hand2 := TexasHoldemBestHand(tt.community, tt.hole2)
+			hand1 := mustBestHand(t, TexasHoldemBestHand, tt.community, tt.hole1)
+			hand2 := mustBestHand(t, TexasHoldemBestHand, tt.community, tt.hole2)
 
 			if tt.winner == 1 {
 				if hand1.Less(hand2) {
@@ -1034,3 +1129,67 @@ func TestPlayerHandComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestWheelHandNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		community []Card
+		hole      []Card
+		expected  string
+	}{
+		{
+			name: "Wheel",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Club, Rank: "3"},
+				{Suit: Heart, Rank: "4"},
+				{Suit: Diamond, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+			},
+			hole: []Card{
+				{Suit: Club, Rank: "A"},
+				{Suit: Heart, Rank: "9"},
+			},
+			expected: "five-high straight",
+		},
+		{
+			name: "Steel Wheel",
+			community: []Card{
+				{Suit: Spade, Rank: "2"},
+				{Suit: Spade, Rank: "3"},
+				{Suit: Spade, Rank: "4"},
+				{Suit: Spade, Rank: "5"},
+				{Suit: Club, Rank: "K"},
+			},
+			hole: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "9"},
+			},
+			expected: "five-high straight flush",
+		},
+		{
+			name: "Royal Flush",
+			community: []Card{
+				{Suit: Spade, Rank: "10"},
+				{Suit: Spade, Rank: "J"},
+				{Suit: Spade, Rank: "Q"},
+				{Suit: Spade, Rank: "K"},
+				{Suit: Club, Rank: "2"},
+			},
+			hole: []Card{
+				{Suit: Spade, Rank: "A"},
+				{Suit: Heart, Rank: "9"},
+			},
+			expected: "royal flush",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand := mustBestHand(t, TexasHoldemBestHand, tt.community, tt.hole)
+			if hand.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, hand.String())
+			}
+		})
+	}
+}
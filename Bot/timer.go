@@ -0,0 +1,123 @@
+package Bot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Starts a new turn, resetting the action clock
+func (g *Game) startTurn() {
+	g.Turn++
+	g.TurnTime = 0
+}
+
+// Returns the players the action clock is waiting on. Everyone still to
+// discard or to agree to run it twice shares a single clock, and otherwise
+// it's one player's turn
+func (g *Game) waitingOn() []*Player {
+	switch g.State {
+	case HandsDealt:
+		if player := g.GetCurrentPlayer(); player != nil {
+			return []*Player{player}
+		}
+	case Drawing:
+		if len(g.DrawOrder) > 0 {
+			return g.DrawOrder[:1]
+		}
+	case Discarding:
+		var waiting []*Player
+		for _, player := range g.playersInPot() {
+			if g.AwaitingDiscard[player] {
+				waiting = append(waiting, player)
+			}
+		}
+		return waiting
+	case ChoosingRunouts:
+		return g.waitingToRunTwice()
+	}
+	return nil
+}
+
+// NeedsClock returns the turn that the action clock needs starting for, if
+// the table uses one and it isn't already running for the current turn
+func (g *Game) NeedsClock() (int, bool) {
+	if g.Options.Timer == 0 || len(g.waitingOn()) == 0 || g.clockTurn == g.Turn {
+		return 0, false
+	}
+	g.clockTurn = g.Turn
+	return g.Turn, true
+}
+
+// Tick runs the action clock for a second of the given turn. The players are
+// pinged at half time, then a player acting on their own uses up their time
+// bank before the clock acts for them. Returns the messages to send, and
+// whether the clock has stopped
+func (g *Game) Tick(turn int) ([]string, bool) {
+	waiting := g.waitingOn()
+	if g.Turn != turn || len(waiting) == 0 || g.Options.Timer == 0 {
+		return nil, true
+	}
+
+	limit := g.Options.Timer
+	g.TurnTime++
+
+	if g.TurnTime < limit {
+		if g.TurnTime == limit/2 {
+			mentions := make([]string, len(waiting))
+			for i, player := range waiting {
+				mentions[i] = player.User.Mention()
+			}
+			return []string{fmt.Sprintf("%s, you have %d seconds left to act.", strings.Join(mentions, ", "), limit-g.TurnTime)}, false
+		}
+		return nil, false
+	}
+
+	// Time banks are only for a player whose turn it is, not for a clock that
+	// several players share
+	if g.State == HandsDealt || g.State == Drawing {
+		player := waiting[0]
+		if g.TurnTime == limit && player.TimeBank > 0 {
+			return []string{fmt.Sprintf("%s is out of time, and is using their time bank of %d seconds.", player.User.Mention(), player.TimeBank)}, false
+		}
+		if g.TurnTime > limit && player.TimeBank > 0 {
+			player.TimeBank--
+			if player.TimeBank > 0 {
+				return nil, false
+			}
+		}
+	}
+
+	return g.TimeOut(), true
+}
+
+// TimeOut acts for the players the clock is waiting on when they run out of
+// time. A player drawing stands pat, players discarding throw away the first
+// cards in their hand, players who haven't agreed to run it twice run it once,
+// and a player betting checks if they can and folds otherwise
+func (g *Game) TimeOut() []string {
+	switch g.State {
+	case Drawing:
+		messages := []string{fmt.Sprintf("%s ran out of time.", g.DrawOrder[0].Name)}
+		return append(messages, g.Draw(nil)...)
+	case Discarding:
+		messages := []string{}
+		count := g.Type.Streets[g.Street].Discard
+		for _, player := range g.waitingOn() {
+			messages = append(messages, fmt.Sprintf("%s ran out of time.", player.Name))
+			messages = append(messages, g.Discard(player.User, slices.Clone(player.Cards[:count]))...)
+		}
+		return messages
+	case ChoosingRunouts:
+		messages := []string{fmt.Sprintf("%s ran out of time, so the board is run once.", playerNames(g.waitingOn()))}
+		return append(messages, g.runOnce()...)
+	}
+
+	player := g.GetCurrentPlayer()
+	if player.CurBet == g.PotManager.CurBet() {
+		messages := []string{fmt.Sprintf("%s ran out of time and checks.", player.Name)}
+		return append(messages, g.Check()...)
+	}
+	messages := []string{fmt.Sprintf("%s ran out of time and folds.", player.Name)}
+	return append(messages, g.Fold()...)
+}
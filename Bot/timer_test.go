@@ -0,0 +1,184 @@
+package Bot
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Ticks the clock for the current turn until it stops, returning every
+// message sent and how many seconds it ran for
+func runClock(game *Game) ([]string, int) {
+	var messages []string
+	turn := game.Turn
+	for seconds := 1; ; seconds++ {
+		tick, done := game.Tick(turn)
+		messages = append(messages, tick...)
+		if done {
+			return messages, seconds
+		}
+	}
+}
+
+func TestActionClock(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeBank int
+		seconds  int
+	}{
+		{name: "Timer Only", timeBank: 0, seconds: 4},
+		{name: "With Time Bank", timeBank: 3, seconds: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newTestGame("holdem", 3)
+			game.SetOption([]string{"timer", "4"})
+			game.SetOption([]string{"timebank", strconv.Itoa(tt.timeBank)})
+			game.DealHands()
+			player := game.GetCurrentPlayer()
+
+			if _, ok := game.NeedsClock(); !ok {
+				t.Fatalf("expected the clock to need starting")
+			}
+			if _, ok := game.NeedsClock(); ok {
+				t.Errorf("expected the clock to only be started once per turn")
+			}
+
+			messages, seconds := runClock(game)
+			if seconds != tt.seconds {
+				t.Errorf("expected the clock to run for %d seconds, got %d", tt.seconds, seconds)
+			}
+			if !strings.Contains(messages[0], "2 seconds left") {
+				t.Errorf("expected a ping at half time, got %v", messages)
+			}
+			if player.TimeBank != 0 {
+				t.Errorf("expected the time bank to be used up, got %d seconds left", player.TimeBank)
+			}
+
+			// Facing the big blind, running out of time folds
+			if _, ok := game.PotManager.InPot()[player]; ok {
+				t.Errorf("expected %s to fold when their time ran out", player.Name)
+			}
+			if game.GetCurrentPlayer() == player {
+				t.Errorf("expected the turn to move on")
+			}
+		})
+	}
+}
+
+func TestActionClockChecks(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.SetOption([]string{"timer", "2"})
+	game.DealHands()
+	game.Call()
+	game.Call()
+
+	// The big blind can check, so that's what happens when they time out
+	big := game.GetCurrentPlayer()
+	messages, _ := runClock(game)
+	if !strings.Contains(strings.Join(messages, "\n"), big.Name+" ran out of time and checks.") || game.Street != 1 {
+		t.Errorf("expected the big blind to check, got %v", messages)
+	}
+}
+
+func TestActionClockStops(t *testing.T) {
+	game := newTestGame("holdem", 3)
+	game.SetOption([]string{"timer", "30"})
+	game.DealHands()
+	turn := game.Turn
+
+	game.Tick(turn)
+	game.Call()
+	if messages, done := game.Tick(turn); !done || len(messages) > 0 {
+		t.Errorf("expected the clock to stop once the player acts, got %v", messages)
+	}
+	if game.TurnTime != 0 {
+		t.Errorf("expected the next turn's clock to start from zero, got %d", game.TurnTime)
+	}
+}
+
+func TestActionClockDraws(t *testing.T) {
+	game := newTestGame("draw", 3)
+	game.SetOption([]string{"timer", "2"})
+	game.DealHands()
+	game.Call()
+	game.Call()
+	game.Check()
+	if game.GetState() != Drawing {
+		t.Fatalf("expected a draw after the first round of betting")
+	}
+
+	// Running out of time to draw stands pat, and the next player's clock
+	// starts
+	drawer := game.DrawOrder[0]
+	hand := slices.Clone(drawer.Cards)
+	if _, ok := game.NeedsClock(); !ok {
+		t.Fatalf("expected the clock to run during the draw")
+	}
+	messages, _ := runClock(game)
+	if !strings.Contains(strings.Join(messages, "\n"), drawer.Name+" stands pat.") || !slices.Equal(drawer.Cards, hand) {
+		t.Errorf("expected %s to stand pat, got %v", drawer.Name, messages)
+	}
+	if game.DrawOrder[0] == drawer {
+		t.Errorf("expected the draw to move on")
+	}
+	if _, ok := game.NeedsClock(); !ok {
+		t.Errorf("expected the clock to start for the next player to draw")
+	}
+}
+
+func TestActionClockDiscards(t *testing.T) {
+	game := newTestGame("pineapple", 3)
+	game.SetOption([]string{"timer", "2"})
+	game.DealHands()
+	game.Call()
+	game.Call()
+	game.Check()
+	if game.GetState() != Discarding {
+		t.Fatalf("expected a discard after the first round of betting")
+	}
+
+	// Everyone who hasn't discarded when the time runs out throws away a card
+	first := game.Players[0]
+	game.Discard(first.User, first.Cards[:1])
+	if _, ok := game.NeedsClock(); !ok {
+		t.Fatalf("expected the clock to run during the discard")
+	}
+	runClock(game)
+	if game.GetState() != HandsDealt || len(game.Community) != 3 {
+		t.Fatalf("expected the flop to be dealt once everyone has discarded")
+	}
+	for _, player := range game.Players {
+		if len(player.Cards) != 2 {
+			t.Errorf("expected %s to have discarded down to 2 cards, has %d", player.Name, len(player.Cards))
+		}
+	}
+}
+
+func TestActionClockRunouts(t *testing.T) {
+	game := newTestGame("holdem", 2)
+	game.SetOption([]string{"timer", "2"})
+	dealer, other := game.Players[0], game.Players[1]
+	game.DealHands()
+	game.RunItTwice(other.User)
+	game.AllIn()
+	game.Call()
+	if game.GetState() != ChoosingRunouts {
+		t.Fatalf("expected to wait for %s to agree to run it twice", dealer.Name)
+	}
+
+	// Not answering in time runs the board once
+	if _, ok := game.NeedsClock(); !ok {
+		t.Fatalf("expected the clock to run while waiting to run it twice")
+	}
+	messages, _ := runClock(game)
+	output := strings.Join(messages, "\n")
+	if !strings.Contains(output, dealer.Name+" ran out of time, so the board is run once.") || strings.Contains(output, "**Board 2:**") {
+		t.Errorf("expected the board to be run once, got %v", messages)
+	}
+	if game.HandInProgress() {
+		t.Errorf("expected the hand to be over")
+	}
+}
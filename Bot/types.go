@@ -6,17 +6,140 @@ type GameType int
 const (
 	TexasHoldemType GameType = iota
 	PotLimitOmahaType
+	PotLimitOmahaHiLoType
+	ShortDeckHoldemType
+	SevenCardStudType
+	FiveCardDrawType
+	DeuceSevenTripleDrawType
+	RazzType
+	PineappleType
+	CrazyPineappleType
+	PotLimitOmaha5Type
+	PotLimitOmaha6Type
+	BigOType
 )
 
+// NewPokerType creates a new game of the given type, returning false if the
+// type isn't known
+func NewPokerType(gameType GameType) (PokerType, bool) {
+	switch gameType {
+	case TexasHoldemType:
+		return NewTexasHoldem(), true
+	case PotLimitOmahaType:
+		return NewPotLimitOmaha(), true
+	case PotLimitOmahaHiLoType:
+		return NewPotLimitOmahaHiLo(), true
+	case ShortDeckHoldemType:
+		return NewShortDeckHoldem(), true
+	case SevenCardStudType:
+		return NewSevenCardStud(), true
+	case FiveCardDrawType:
+		return NewFiveCardDraw(), true
+	case DeuceSevenTripleDrawType:
+		return NewDeuceSevenTripleDraw(), true
+	case RazzType:
+		return NewRazz(), true
+	case PineappleType:
+		return NewPineapple(), true
+	case CrazyPineappleType:
+		return NewCrazyPineapple(), true
+	case PotLimitOmaha5Type:
+		return NewPotLimitOmaha5(), true
+	case PotLimitOmaha6Type:
+		return NewPotLimitOmaha6(), true
+	case BigOType:
+		return NewBigO(), true
+	}
+	return PokerType{}, false
+}
+
 // BestHandFunc defines the signature for functions that determine the best possible hand
-// given community cards and hole cards
-type BestHandFunc func(community []Card, hole []Card) Hand
+// given community cards and hole cards, returning an error if no hand can be made
+// out of the cards given
+type BestHandFunc func(community []Card, hole []Card) (Hand, error)
+
+// Street is a round of dealing or drawing in a hand, which is followed by a
+// round of betting
+type Street struct {
+	// The name of the street, e.g. "the flop" or "fourth street"
+	Name string
+	// The number of cards dealt face-down to each player
+	Down int
+	// The number of cards dealt face-up to each player
+	Up int
+	// The number of community cards dealt to the board
+	Community int
+	// Whether players get to discard cards and draw replacements
+	Draw bool
+	// The number of cards each player has to discard before the street is
+	// dealt
+	Discard int
+}
 
 type PokerType struct {
 	GameType GameType
 	Deck     Deck
 	BestHand BestHandFunc
-	DealHand func() []Card
 	String   func() string
-	MaxBet   func(player *Player, pm *PotManager) int
+	// The betting structure the game is usually played with
+	Limit BettingStructure
+	// The streets dealt in each hand, starting with the initial deal
+	Streets []Street
+	// Determines the best qualifying low hand in split-pot games, or nil if
+	// the whole pot goes to the best high hand
+	LowHand BestHandFunc
+	// Picks which of the players' first face-up cards has to pay the bring-in,
+	// or nil if the game uses blinds instead
+	BringIn func(doors []Card) int
+	// Scores a player's face-up cards to decide who acts first after the
+	// first street, or nil if action starts left of the dealer
+	Showing func(up []Card) int
+}
+
+// Returns the number of cards each player holds by the end of a hand
+func (pt PokerType) HoleCards() int {
+	total := 0
+	for _, street := range pt.Streets {
+		total += street.Down + street.Up - street.Discard
+	}
+	return total
+}
+
+// Returns the number of community cards dealt over a whole hand
+func (pt PokerType) BoardSize() int {
+	total := 0
+	for _, street := range pt.Streets {
+		total += street.Community
+	}
+	return total
+}
+
+// Returns the most players that can be dealt into a hand without running out
+// of cards. Games without a board deal a shared community card instead of the
+// last street once the deck runs out, so they only need enough cards for the
+// streets before it
+func (pt PokerType) MaxPlayers() int {
+	dealt := 0
+	for _, street := range pt.Streets {
+		dealt += street.Down + street.Up
+	}
+
+	cards := len(pt.Deck.AllCards()) - pt.BoardSize()
+	if pt.BoardSize() == 0 {
+		last := pt.Streets[len(pt.Streets)-1]
+		dealt -= last.Down + last.Up
+		cards--
+	}
+	return cards / dealt
+}
+
+// The streets of a game with two betting rounds before the board is complete,
+// dealing the given number of hole cards to start
+func boardStreets(holeCards int) []Street {
+	return []Street{
+		{Name: "the hole cards", Down: holeCards},
+		{Name: "the flop", Community: 3},
+		{Name: "the turn", Community: 1},
+		{Name: "the river", Community: 1},
+	}
 }
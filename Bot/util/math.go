@@ -7,3 +7,11 @@ func Min(a, b int) int {
 	}
 	return b
 }
+
+// Max returns the maximum of two integers
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}